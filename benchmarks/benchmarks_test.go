@@ -96,6 +96,24 @@ func BenchmarkParseWithLocale(b *testing.B) {
 	}
 }
 
+func BenchmarkParseWithOptionsNarrowNames(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	opts := lunes.Options{NarrowNames: true}
+	for i := 0; i < b.N; i++ {
+		_, err = lunes.ParseWithOptions("Monday January _2 2006 15:04:05", "miércoles septiembre 27 1988 11:53:29", locale, opts)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
 func BenchmarkParseInLocationWithLocale(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -113,6 +131,174 @@ func BenchmarkParseInLocationWithLocale(b *testing.B) {
 	}
 }
 
+func BenchmarkAppendTranslate(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	value := []byte("lunes oct 27 1988 11:53:29")
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf, err = lunes.AppendTranslate(buf[:0], "Monday Jan _2 2006 15:04:05", value, locale)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	value := []byte("lunes oct 27 1988 11:53:29")
+	for i := 0; i < b.N; i++ {
+		_, err = lunes.ParseBytes("Monday Jan _2 2006 15:04:05", value, locale)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseBytesInLocation(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	value := []byte("lunes oct 27 1988 11:53:29")
+	for i := 0; i < b.N; i++ {
+		_, err = lunes.ParseBytesInLocation("Monday Jan _2 2006 15:04:05", value, time.UTC, locale)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkCompiledLayoutTranslate(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	layout, err := lunes.CompileLayout("Monday Jan _2 2006 15:04:05", locale)
+	if err != nil {
+		b.Error(err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		_, err = layout.Translate("lunes oct 27 1988 11:53:29")
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkCompiledLayoutParse(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	layout, err := lunes.CompileLayout("Monday Jan _2 2006 15:04:05", locale)
+	if err != nil {
+		b.Error(err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		_, err = layout.Parse("lunes oct 27 1988 11:53:29")
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkCompiledLayoutParseInLocation(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	layout, err := lunes.CompileLayout("Monday Jan _2 2006 15:04:05", locale)
+	if err != nil {
+		b.Error(err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		_, err = layout.ParseInLocation("lunes oct 27 1988 11:53:29", time.UTC)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkCompiledLayoutAppendTranslate(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	layout, err := lunes.CompileLayout("Monday Jan _2 2006 15:04:05", locale)
+	if err != nil {
+		b.Error(err)
+	}
+
+	value := []byte("lunes oct 27 1988 11:53:29")
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf, err = layout.AppendTranslate(buf[:0], value)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkCompiledLayoutParseBytes(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	locale, err := lunes.NewDefaultLocale(lunes.LocaleEsES)
+	if err != nil {
+		b.Error(err)
+	}
+
+	layout, err := lunes.CompileLayout("Monday Jan _2 2006 15:04:05", locale)
+	if err != nil {
+		b.Error(err)
+	}
+
+	value := []byte("lunes oct 27 1988 11:53:29")
+	for i := 0; i < b.N; i++ {
+		_, err = layout.ParseBytes(value)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
 func BenchmarkParseMonday(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
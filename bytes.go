@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// translatedPool holds the buffers the values are translated to by the bytes parsing
+// functions, so they don't allocate a buffer on each call.
+var translatedPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 64)
+		return &buf
+	},
+}
+
+// AppendTranslate is like TranslateWithLocale, but it appends the translated value to dst
+// and returns the extended buffer. The value is read in place, so translating values into
+// a reused buffer, with enough capacity, does not allocate.
+func AppendTranslate(dst []byte, layout string, value []byte, locale Locale) ([]byte, error) {
+	return appendTranslateBytes(dst, layout, nil, value, locale, &Options{}, nil)
+}
+
+// ParseBytes is like ParseWithLocale, but it receives the value as a byte slice, which is
+// read in place. It does not allocate when parsing values the time package would not
+// allocate for either.
+func ParseBytes(layout string, value []byte, locale Locale) (time.Time, error) {
+	return parseBytes(layout, nil, value, nil, locale, &Options{})
+}
+
+// ParseBytesInLocation is like ParseInLocationWithLocale, but it receives the value as a
+// byte slice, as ParseBytes does.
+func ParseBytesInLocation(layout string, value []byte, location *time.Location, locale Locale) (time.Time, error) {
+	return parseBytes(layout, nil, value, location, locale, &Options{})
+}
+
+// appendTranslateBytes is like appendTranslateElems, but it receives the value as a byte
+// slice. On errors, dst is returned unchanged.
+func appendTranslateBytes(dst []byte, layout string, elems []layoutElem, value []byte, locale Locale, opts *Options, state *parseState) ([]byte, error) {
+	buf, err := appendTranslateElems(dst, layout, elems, bytesString(value), locale, opts, state)
+	if err != nil {
+		// errors might retain the value, which must not reference the caller's buffer,
+		// so they are built again from a copy.
+		_, err = appendTranslateElems(dst, layout, elems, string(value), locale, opts, state)
+		return dst, err
+	}
+
+	return buf, nil
+}
+
+// parseBytes is like parse, but it receives the value as a byte slice, translating it to
+// a pooled buffer.
+func parseBytes(layout string, elems []layoutElem, value []byte, location *time.Location, locale Locale, opts *Options) (time.Time, error) {
+	if t, ok := parseNative(layout, elems, bytesString(value), location, locale, opts); ok {
+		return t, nil
+	}
+
+	if opts.readsValue() {
+		if err := nativeMismatchError(layout, elems, string(value), locale, opts); err != nil {
+			return time.Time{}, newParseError(layout, elems, string(value), locale, opts, err)
+		}
+	}
+
+	bufp := translatedPool.Get().(*[]byte)
+	defer translatedPool.Put(bufp)
+
+	state := parseState{yearOffset: -1}
+	buf, err := appendTranslateBytes((*bufp)[:0], layout, elems, value, locale, opts, &state)
+	if err == nil {
+		*bufp = buf
+
+		var t time.Time
+		t, err = state.parseBytes(layout, buf, location)
+		if err != nil && opts.IgnoreTrailingInput {
+			if pv, ok := trimExtraText(bytesString(buf), err); ok {
+				t, err = state.parseBytes(layout, buf[:len(pv)], location)
+			}
+		}
+
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, newParseError(layout, elems, string(value), locale, opts, err)
+}
+
+// parseBytes is like parse, but it reads the translated value in place. The time package
+// retains the value in its errors, and the time zones abbreviations in the locations it
+// creates, so those are parsed from a copy instead, as the buffer is reused.
+func (s *parseState) parseBytes(layout string, value []byte, location *time.Location) (time.Time, error) {
+	if strings.Contains(layout, "MST") {
+		return s.parse(layout, string(value), location)
+	}
+
+	t, err := s.parse(layout, bytesString(value), location)
+	if err != nil {
+		return s.parse(layout, string(value), location)
+	}
+
+	return t, nil
+}
+
+// bytesString returns the bytes as a string without copying them. The string must not
+// be retained after the call it is passed to, as the bytes might be modified.
+func bytesString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseBytes(t *testing.T) {
+	testParseFunc(t, parseTests, time.Parse, func(format string, value string, lang string) (time.Time, error) {
+		locale, err := NewDefaultLocale(lang)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return ParseBytes(format, []byte(value), locale)
+	})
+}
+
+func TestParseBytesInLocation(t *testing.T) {
+	testParseFunc(t, parseTests, func(format, stdValue string) (time.Time, error) {
+		return time.ParseInLocation(format, stdValue, defaultLocation)
+	}, func(format string, value string, lang string) (time.Time, error) {
+		locale, err := NewDefaultLocale(lang)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return ParseBytesInLocation(format, []byte(value), defaultLocation, locale)
+	})
+}
+
+func TestCompiledLayoutParseBytes(t *testing.T) {
+	testParseFunc(t, parseTests, time.Parse, func(format string, value string, lang string) (time.Time, error) {
+		locale, err := NewDefaultLocale(lang)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		layout, err := CompileLayout(format, locale)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return layout.ParseBytes([]byte(value))
+	})
+}
+
+func TestAppendTranslate(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+
+	tests := []struct {
+		layout string
+		value  string
+		locale Locale
+	}{
+		{layout: "Monday Jan _2 2006 15:04:05", value: "lunes oct 27 1988 11:53:29", locale: es},
+		{layout: "Monday, 2 January 2006 3:04 PM", value: "jueves, 27 octubre 1988 11:53 p. m.", locale: es},
+		{layout: "02/01/2006 AD", value: "27/10/44 a. C.", locale: esEras},
+		{layout: "__2 2006", value: "  9 1988", locale: es},
+		{layout: "Mon Jan", value: "mar. abc", locale: es},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			want, wantErr := TranslateWithLocale(tt.layout, tt.value, tt.locale)
+
+			dst := []byte("prefix: ")
+			got, err := AppendTranslate(dst, tt.layout, []byte(tt.value), tt.locale)
+			if wantErr != nil {
+				if !errors.Is(err, wantErr) || string(got) != "prefix: " {
+					t.Errorf("expected: 'prefix: ' ('%v'), got: '%s' ('%v')", wantErr, got, err)
+				}
+				return
+			}
+
+			if string(got) != "prefix: "+want || err != nil {
+				t.Errorf("expected: 'prefix: %s', got: '%s' ('%v')", want, got, err)
+			}
+		})
+	}
+}
+
+func TestParseBytesRetainedValues(t *testing.T) {
+	locale, _ := NewDefaultLocale(LocaleEs)
+
+	// the errors must not retain the caller's buffer
+	value := []byte("lunes abc 27 1988")
+	_, err := ParseBytes("Monday Jan 2 2006", value, locale)
+	copy(value, "xxxxx")
+
+	expected := newLayoutMismatchError("Jan", "lunes abc 27 1988")
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error: '%v', got: '%v'", expected, err)
+	}
+
+	// the time zones abbreviations must not retain the pooled buffer
+	got, err := ParseBytes("2 Jan 2006 15:04 MST", []byte("27 oct 1988 11:53 XYZ"), locale)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	_, _ = ParseBytes("2 Jan 2006 15:04 MST", []byte("28 nov 1989 12:54 ABC"), locale)
+	if name, _ := got.Zone(); name != "XYZ" {
+		t.Errorf("expected zone: 'XYZ', got: '%s'", name)
+	}
+}
+
+func TestParseBytesAllocs(t *testing.T) {
+	locale, _ := NewDefaultLocale(LocaleEs)
+	layout, _ := CompileLayout("Monday Jan _2 2006 15:04:05", locale)
+	value := []byte("lunes oct 27 1988 11:53:29")
+	dst := make([]byte, 0, 64)
+
+	allocs := map[string]func(){
+		"AppendTranslate": func() {
+			_, _ = AppendTranslate(dst[:0], "Monday Jan _2 2006 15:04:05", value, locale)
+		},
+		"ParseBytes": func() {
+			_, _ = ParseBytes("Monday Jan _2 2006 15:04:05", value, locale)
+		},
+		"Layout.AppendTranslate": func() {
+			_, _ = layout.AppendTranslate(dst[:0], value)
+		},
+		"Layout.ParseBytes": func() {
+			_, _ = layout.ParseBytes(value)
+		},
+	}
+
+	for name, fn := range allocs {
+		if n := testing.AllocsPerRun(100, fn); n != 0 {
+			t.Errorf("%s: expected no allocations, got: %v", name, n)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import "time"
+
+// Layout is a layout compiled for a locale by [CompileLayout]. Its elements and the locale
+// tables they are translated with are found once, so translating and parsing several values
+// with the same layout performs better than using [TranslateWithLocale] or [ParseWithLocale].
+// A Layout is immutable, and safe for concurrent use by multiple goroutines.
+type Layout struct {
+	layout string
+	locale Locale
+	opts   Options
+	elems  []layoutElem
+}
+
+// CompileLayout compiles the layout for the given locale. The layout has the same format as
+// the one received by [TranslateWithLocale]. If the locale does not support a layout element
+// specified on the layout, it returns an ErrUnsupportedLayoutElem error.
+func CompileLayout(layout string, locale Locale) (*Layout, error) {
+	return CompileLayoutWithOptions(layout, locale, Options{})
+}
+
+// CompileLayoutWithOptions is like CompileLayout, but it receives an Options argument
+// enabling optional translation behaviors.
+func CompileLayoutWithOptions(layout string, locale Locale, opts Options) (*Layout, error) {
+	elems, err := newLayoutElems(layout, locale, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Layout{layout: layout, locale: locale, opts: opts, elems: elems}, nil
+}
+
+// String returns the layout the Layout was compiled from.
+func (l *Layout) String() string {
+	return l.layout
+}
+
+// Locale returns the locale the Layout was compiled for.
+func (l *Layout) Locale() Locale {
+	return l.locale
+}
+
+// Translate is like TranslateWithLocale, using the compiled layout and locale.
+func (l *Layout) Translate(value string) (string, error) {
+	return translateElems(l.layout, l.elems, value, l.locale, &l.opts, nil)
+}
+
+// Parse is like ParseWithLocale, using the compiled layout and locale.
+func (l *Layout) Parse(value string) (time.Time, error) {
+	return l.parse(value, nil)
+}
+
+// ParseInLocation is like ParseInLocationWithLocale, using the compiled layout and locale.
+func (l *Layout) ParseInLocation(value string, location *time.Location) (time.Time, error) {
+	return l.parse(value, location)
+}
+
+// ParseWithResult is like the ParseWithResult function, using the compiled layout, locale,
+// and options.
+func (l *Layout) ParseWithResult(value string) (ParseResult, error) {
+	return parseResult(l.layout, l.elems, value, nil, l.locale, &l.opts)
+}
+
+// AppendTranslate is like the AppendTranslate function, using the compiled layout and locale.
+func (l *Layout) AppendTranslate(dst []byte, value []byte) ([]byte, error) {
+	return appendTranslateBytes(dst, l.layout, l.elems, value, l.locale, &l.opts, nil)
+}
+
+// ParseBytes is like the ParseBytes function, using the compiled layout and locale.
+func (l *Layout) ParseBytes(value []byte) (time.Time, error) {
+	return parseBytes(l.layout, l.elems, value, nil, l.locale, &l.opts)
+}
+
+// ParseBytesInLocation is like the ParseBytesInLocation function, using the compiled layout
+// and locale.
+func (l *Layout) ParseBytesInLocation(value []byte, location *time.Location) (time.Time, error) {
+	return parseBytes(l.layout, l.elems, value, location, l.locale, &l.opts)
+}
+
+func (l *Layout) parse(value string, location *time.Location) (time.Time, error) {
+	return parse(l.layout, l.elems, value, location, l.locale, &l.opts)
+}
@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func compiledParse(format string, value string, lang string) (time.Time, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	layout, err := CompileLayout(format, locale)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return layout.Parse(value)
+}
+
+func TestCompiledLayoutParse(t *testing.T) {
+	testParseFunc(t, parseTests, time.Parse, compiledParse)
+}
+
+func TestCompiledLayoutParseInLocation(t *testing.T) {
+	testParseFunc(t, parseTests, func(format, stdValue string) (time.Time, error) {
+		return time.ParseInLocation(format, stdValue, defaultLocation)
+	}, func(format string, value string, lang string) (time.Time, error) {
+		locale, err := NewDefaultLocale(lang)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		layout, err := CompileLayout(format, locale)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return layout.ParseInLocation(value, defaultLocation)
+	})
+}
+
+func TestCompiledLayoutTranslate(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+
+	tests := []struct {
+		layout string
+		value  string
+		locale Locale
+	}{
+		{layout: "Monday Jan _2 2006 15:04:05", value: "lunes oct 27 1988 11:53:29", locale: es},
+		{layout: "Monday, 2 January 2006 3:04 PM", value: "jueves, 27 octubre 1988 11:53 p. m.", locale: es},
+		{layout: "02/01/2006 AD", value: "27/10/44 a. C.", locale: esEras},
+		{layout: "__2 2006", value: "  9 1988", locale: es},
+		{layout: "_2 Jan", value: "9", locale: es},
+		{layout: "Mon Jan", value: "mar. abc", locale: es},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			want, wantErr := TranslateWithLocale(tt.layout, tt.value, tt.locale)
+
+			layout, err := CompileLayout(tt.layout, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			got, err := layout.Translate(tt.value)
+			if got != want || !errors.Is(err, wantErr) {
+				t.Errorf("expected: '%s' ('%v'), got: '%s' ('%v')", want, wantErr, got, err)
+			}
+		})
+	}
+}
+
+func TestCompileLayoutUnsupportedLayoutElem(t *testing.T) {
+	locale := newTestLocale(LocaleEn, map[int][]string{longEraNamesField: {"Before Christ", "Anno Domini"}, shortEraNamesField: nil})
+	_, err := CompileLayout("02/01/2006 AD", locale)
+	if expected := newUnsupportedLayoutElemError("AD", locale); !errors.Is(err, expected) {
+		t.Errorf("expected error: '%v', got: '%v'", expected, err)
+	}
+}
+
+func TestCompiledLayoutConcurrentParse(t *testing.T) {
+	locale, _ := NewDefaultLocale(LocaleFr)
+	layout, err := CompileLayout("Monday 2 January 2006 15:04", locale)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	want := time.Date(2024, time.October, 15, 21, 41, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				got, err := layout.Parse("mardi 15 octobre 2024 21:41")
+				if err != nil || !got.Equal(want) {
+					t.Errorf("expected: %v, got: %v ('%v')", want, got, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	amDayPeriodIndex = 1
+	pmDayPeriodIndex = 3
+
+	// noonMinutes is the number of minutes from midnight to noon.
+	noonMinutes = 12 * 60
+)
+
+// pendingDayPeriod holds a matched flexible day period, which might need the hour value
+// to be resolved as AM or PM.
+type pendingDayPeriod struct {
+	// offset is the translated value position where the AM/PM value is written, or -1
+	// if there is no pending day period.
+	offset     int
+	layoutElem string
+	index      int
+	rule       string
+	stdTab     []string
+}
+
+// resolve returns the dayPeriodsStd index (0 for AM, 1 for PM) of the flexible day period.
+// Periods that include hours before and after noon, such as the ones crossing midnight,
+// are resolved using the 12-hour clock value, or are not resolved if the hour is negative.
+func (p *pendingDayPeriod) resolve(hour int) (int, bool) {
+	switch p.index {
+	case amDayPeriodIndex:
+		return 0, true
+	case pmDayPeriodIndex:
+		return 1, true
+	}
+
+	from, before, ok := parseDayPeriodRule(p.rule)
+	if !ok {
+		return 0, false
+	}
+
+	// exact times, such as midnight and noon
+	if before < 0 {
+		if from < noonMinutes {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	if from < before && before <= noonMinutes {
+		return 0, true
+	}
+
+	if from < before && from >= noonMinutes {
+		return 1, true
+	}
+
+	if hour < 0 || hour > 12 {
+		return 0, false
+	}
+
+	am := (hour % 12) * 60
+	inAM, inPM := inDayPeriodRange(am, from, before), inDayPeriodRange(am+noonMinutes, from, before)
+	if inAM == inPM {
+		return 0, false
+	}
+
+	if inPM {
+		return 1, true
+	}
+
+	return 0, true
+}
+
+// inDayPeriodRange reports whether the minutes from midnight are within the [from, before)
+// range, which might cross midnight.
+func inDayPeriodRange(minutes, from, before int) bool {
+	if from < before {
+		return minutes >= from && minutes < before
+	}
+	return minutes >= from || minutes < before
+}
+
+// parseDayPeriodRule parses the "HH:MM" and "HH:MM-HH:MM" rules, returning the minutes from
+// midnight of each part. If the rule is an exact time, before is -1.
+func parseDayPeriodRule(rule string) (from, before int, ok bool) {
+	fromRule, beforeRule, isRange := strings.Cut(rule, "-")
+	from, ok = parseDayPeriodTime(fromRule)
+	if !ok {
+		return 0, 0, false
+	}
+
+	if !isRange {
+		return from, -1, true
+	}
+
+	before, ok = parseDayPeriodTime(beforeRule)
+	return from, before, ok
+}
+
+func parseDayPeriodTime(value string) (int, bool) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, false
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 24 {
+		return 0, false
+	}
+
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, false
+	}
+
+	return h*60 + m, true
+}
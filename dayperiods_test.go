@@ -0,0 +1,221 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var esDayPeriodsTestFields = map[int][]string{
+	longFlexibleDayPeriodsField:  {"", "a. m.", "del mediodía", "p. m.", "de la madrugada", "de la mañana", "", "", "de la tarde", "", "de la noche", ""},
+	shortFlexibleDayPeriodsField: {"", "a. m.", "del mediodía", "p. m.", "de la madrugada", "de la mañana", "", "", "de la tarde", "", "de la noche", ""},
+	dayPeriodRulesField:          {"00:00", "", "12:00", "", "00:00-06:00", "06:00-12:00", "", "", "12:00-20:00", "", "20:00-24:00", ""},
+}
+
+func TestTranslateFlexibleDayPeriods(t *testing.T) {
+	es := newTestLocale(LocaleEs, esDayPeriodsTestFields)
+
+	zh := newTestLocale(LocaleZh, map[int][]string{
+		longFlexibleDayPeriodsField:  {"午夜", "上午", "中午", "下午", "早上", "上午", "", "下午", "晚上", "", "凌晨", ""},
+		shortFlexibleDayPeriodsField: {"午夜", "上午", "中午", "下午", "早上", "上午", "", "下午", "晚上", "", "凌晨", ""},
+		dayPeriodRulesField:          {"00:00", "", "", "", "05:00-08:00", "08:00-12:00", "12:00-13:00", "13:00-19:00", "19:00-24:00", "", "00:00-05:00", ""},
+	})
+
+	en := newTestLocale(LocaleEn, map[int][]string{
+		longFlexibleDayPeriodsField:  {"midnight", "AM", "noon", "PM", "in the morning", "", "in the afternoon", "", "in the evening", "", "at night", ""},
+		shortFlexibleDayPeriodsField: {"midnight", "AM", "noon", "PM", "in the morning", "", "in the afternoon", "", "in the evening", "", "at night", ""},
+		dayPeriodRulesField:          {"00:00", "", "12:00", "", "06:00-12:00", "", "12:00-18:00", "", "18:00-21:00", "", "21:00-06:00", ""},
+	})
+
+	tests := []struct {
+		name   string
+		layout string
+		value  string
+		locale Locale
+		want   string
+	}{
+		{
+			name:   "AfternoonPeriod",
+			layout: "3:04 PM",
+			value:  "3:04 de la tarde",
+			locale: es,
+			want:   "3:04 PM",
+		},
+		{
+			name:   "MorningPeriod",
+			layout: "03:04 PM",
+			value:  "09:30 de la mañana",
+			locale: es,
+			want:   "09:30 AM",
+		},
+		{
+			name:   "NoonPeriod",
+			layout: "3:04 pm",
+			value:  "12:00 del mediodía",
+			locale: es,
+			want:   "12:00 pm",
+		},
+		{
+			name:   "StandardDayPeriod",
+			layout: "3:04 PM",
+			value:  "3:04 p. m.",
+			locale: es,
+			want:   "3:04 PM",
+		},
+		{
+			name:   "PeriodBeforeHour",
+			layout: "PM3:04",
+			value:  "下午3:04",
+			locale: zh,
+			want:   "PM3:04",
+		},
+		{
+			name:   "EveningBeforeHour",
+			layout: "2006年01月02日 PM3:04",
+			value:  "2024年10月16日 晚上8:15",
+			locale: zh,
+			want:   "2024年10月16日 PM8:15",
+		},
+		{
+			name:   "NightBeforeMidnight",
+			layout: "3:04 PM",
+			value:  "10:30 at night",
+			locale: en,
+			want:   "10:30 PM",
+		},
+		{
+			name:   "NightAfterMidnight",
+			layout: "03:04 PM",
+			value:  "02:30 at night",
+			locale: en,
+			want:   "02:30 AM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateWithLocale(tt.layout, tt.value, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected value %q, got: %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Parse", func(t *testing.T) {
+		got, err := ParseInLocationWithLocale("Jan 2 2006 3:04 PM", "oct 16 2024 8:45 de la noche", defaultLocation, es)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		want := time.Date(2024, time.October, 16, 20, 45, 0, 0, defaultLocation)
+		if !got.Equal(want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("UnresolvedPeriod", func(t *testing.T) {
+		value := "at night"
+		_, err := TranslateWithLocale("PM", value, en)
+		expectedErr := newLayoutMismatchError("PM", value)
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+		}
+	})
+
+	t.Run("HourOutsidePeriod", func(t *testing.T) {
+		value := "7:00 at night"
+		_, err := TranslateWithLocale("3:04 PM", value, en)
+		expectedErr := newLayoutMismatchError("PM", value)
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+		}
+	})
+
+	t.Run("NonFlexibleLocale", func(t *testing.T) {
+		value := "3:04 de la tarde"
+		_, err := TranslateWithLocale("3:04 PM", value, &customLocale{es})
+		expectedErr := newLayoutMismatchError("PM", value)
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+		}
+	})
+}
+
+func TestParseFlexibleDayPeriodsLocales(t *testing.T) {
+	tests := []struct {
+		lang  string
+		value string
+		want  time.Time
+	}{
+		{lang: LocaleEs, value: "3:04 de la tarde", want: time.Date(0, time.January, 1, 15, 4, 0, 0, time.UTC)},
+		{lang: LocaleEs, value: "6:30 de la mañana", want: time.Date(0, time.January, 1, 6, 30, 0, 0, time.UTC)},
+		{lang: LocaleEs, value: "3:04 p. m.", want: time.Date(0, time.January, 1, 15, 4, 0, 0, time.UTC)},
+		{lang: LocaleFr, value: "9:30 du soir", want: time.Date(0, time.January, 1, 21, 30, 0, 0, time.UTC)},
+		{lang: LocaleFr, value: "9:30 du matin", want: time.Date(0, time.January, 1, 9, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.value, func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, longFlexibleDayPeriodsField, dayPeriodRulesField)
+
+			got, err := Parse("3:04 PM", tt.value, tt.lang)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v ('%v')", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestResolveDayPeriod(t *testing.T) {
+	tests := []struct {
+		rule   string
+		index  int
+		hour   int
+		want   int
+		wantOK bool
+	}{
+		{rule: "", index: amDayPeriodIndex, hour: -1, want: 0, wantOK: true},
+		{rule: "", index: pmDayPeriodIndex, hour: -1, want: 1, wantOK: true},
+		{rule: "00:00", index: 0, hour: -1, want: 0, wantOK: true},
+		{rule: "12:00", index: 2, hour: -1, want: 1, wantOK: true},
+		{rule: "06:00-12:00", index: 4, hour: -1, want: 0, wantOK: true},
+		{rule: "12:00-18:00", index: 6, hour: -1, want: 1, wantOK: true},
+		{rule: "21:00-06:00", index: 10, hour: -1, wantOK: false},
+		{rule: "21:00-06:00", index: 10, hour: 11, want: 1, wantOK: true},
+		{rule: "21:00-06:00", index: 10, hour: 12, want: 0, wantOK: true},
+		{rule: "21:00-06:00", index: 10, hour: 7, wantOK: false},
+		{rule: "10:00-14:00", index: 6, hour: 11, want: 0, wantOK: true},
+		{rule: "10:00-14:00", index: 6, hour: 1, want: 1, wantOK: true},
+		{rule: "", index: 6, hour: 1, wantOK: false},
+		{rule: "25:00", index: 6, hour: 1, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		period := pendingDayPeriod{index: tt.index, rule: tt.rule}
+		got, ok := period.resolve(tt.hour)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("rule %q hour %d: expected (%d, %v), got: (%d, %v)", tt.rule, tt.hour, tt.want, tt.wantOK, got, ok)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	namesIndexOnce sync.Once
+	// namesIndex maps the case-folded words of the locales days, months, and day periods
+	// names to the languages using them.
+	namesIndex map[string][]string
+)
+
+// namesIndexFields holds the tables fields indexed by the names index.
+var namesIndexFields = []int{
+	shortDayNamesField,
+	longDayNamesField,
+	shortMonthNamesField,
+	longMonthNamesField,
+	dayPeriodsField,
+	standAloneShortDayNamesField,
+	standAloneLongDayNamesField,
+	standAloneShortMonthNamesField,
+	standAloneLongMonthNamesField,
+}
+
+// getNamesIndex returns the names index, building it on first access. The tables are
+// loaded without being cached, so only the index is kept in memory.
+func getNamesIndex() map[string][]string {
+	namesIndexOnce.Do(func() {
+		index := make(map[string][]string)
+		for _, lang := range slices.Sorted(maps.Keys(tableLoaders)) {
+			table := tableLoaders[lang]()
+			for _, field := range namesIndexFields {
+				for _, name := range table[field] {
+					for _, word := range foldedWords(name) {
+						langs := index[word]
+						if len(langs) == 0 || langs[len(langs)-1] != lang {
+							index[word] = append(langs, lang)
+						}
+					}
+				}
+			}
+		}
+		namesIndex = index
+	})
+
+	return namesIndex
+}
+
+// foldedWords splits the value into case-folded words, which are sequences of letters.
+func foldedWords(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r)
+	})
+}
+
+// DetectLocale returns the languages whose days, months, and day periods names match the
+// most words of the value, sorted. If the candidates argument is not empty, only those
+// languages are considered. It uses an index of all the locales names, which is built on
+// first use. If no language matches the value, it returns an empty slice.
+func DetectLocale(value string, candidates []string) []string {
+	ranked, _ := rankLocales(value, candidates)
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	var langs []string
+	for _, r := range ranked {
+		if r.words != ranked[0].words {
+			break
+		}
+		langs = append(langs, r.lang)
+	}
+
+	return langs
+}
+
+// rankedLocale is a language matching rankLocales value words.
+type rankedLocale struct {
+	lang  string
+	words int
+}
+
+// rankLocales returns the languages matching the value words, sorted by the number of
+// matched words, and the number of words of the value.
+func rankLocales(value string, candidates []string) ([]rankedLocale, int) {
+	words := foldedWords(value)
+	if len(words) == 0 {
+		return nil, 0
+	}
+
+	index := getNamesIndex()
+	matches := make(map[string]int)
+	for i, word := range words {
+		// repeated words are counted once
+		if slices.Contains(words[:i], word) {
+			continue
+		}
+
+		for _, lang := range index[word] {
+			if len(candidates) == 0 || slices.Contains(candidates, lang) {
+				matches[lang]++
+			}
+		}
+	}
+
+	ranked := make([]rankedLocale, 0, len(matches))
+	for lang, n := range matches {
+		ranked = append(ranked, rankedLocale{lang, n})
+	}
+
+	slices.SortFunc(ranked, func(a, b rankedLocale) int {
+		if a.words != b.words {
+			return b.words - a.words
+		}
+		return strings.Compare(a.lang, b.lang)
+	})
+
+	return ranked, len(slices.Compact(slices.Sorted(slices.Values(words))))
+}
+
+// ParseAnyResult is the result of parsing a value in an unknown language.
+type ParseAnyResult struct {
+	// Time is the time value the best matching locales parsed.
+	Time time.Time
+	// Locales holds the best matching languages that parsed the value into Time, sorted.
+	// It's empty if the value has no words, as it's not bound to any language.
+	Locales []string
+	// Confidence is the ratio of the value words matching the Locales names, from 0 to 1.
+	Confidence float64
+	// Ambiguous reports whether other languages, matching the same number of words,
+	// parsed the value into a different time value.
+	Ambiguous bool
+}
+
+// ParseAny parses a formatted string in an unknown language, and returns the [time.Time]
+// value it represents, along with the languages that matched it. The languages are
+// detected by [DetectLocale], among the candidates languages, or among all languages if
+// the candidates argument is empty. Then, the value is parsed with each one of them, from
+// the languages matching the most words of the value to the least, until one succeeds.
+// See [Parse] for more details.
+//
+// Values with no words (e.g. "2024-10-15") are parsed using the first candidate language,
+// or English if there are no candidates. If no language matches the value words, it returns
+// an ErrUndetectedLocale error, and if no matching language parses the value, the error
+// of the best matching language.
+func ParseAny(layout string, value string, candidates []string) (ParseAnyResult, error) {
+	for _, lang := range candidates {
+		if _, ok := tableLoaders[lang]; !ok {
+			return ParseAnyResult{}, &ErrUnsupportedLocale{lang}
+		}
+	}
+
+	ranked, words := rankLocales(value, candidates)
+	if words == 0 {
+		lang := LocaleEn
+		if len(candidates) > 0 {
+			lang = candidates[0]
+		}
+
+		t, err := Parse(layout, value, lang)
+		return ParseAnyResult{Time: t}, err
+	}
+
+	if len(ranked) == 0 {
+		return ParseAnyResult{}, newUndetectedLocaleError(value)
+	}
+
+	var result ParseAnyResult
+	var firstErr error
+	for i, r := range ranked {
+		// the best matching languages take precedence
+		if len(result.Locales) > 0 && r.words != ranked[i-1].words {
+			break
+		}
+
+		t, err := Parse(layout, value, r.lang)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if len(result.Locales) == 0 {
+			result.Time = t
+			result.Confidence = float64(r.words) / float64(words)
+		} else if !t.Equal(result.Time) {
+			result.Ambiguous = true
+			continue
+		}
+
+		result.Locales = append(result.Locales, r.lang)
+	}
+
+	if len(result.Locales) == 0 {
+		return ParseAnyResult{}, firstErr
+	}
+
+	return result, nil
+}
+
+// ErrUndetectedLocale indicates that no language matches the provided value.
+type ErrUndetectedLocale struct {
+	Value string
+}
+
+func (u *ErrUndetectedLocale) Error() string {
+	return fmt.Sprintf(`no language matches the value "%s"`, u.Value)
+}
+
+func (u *ErrUndetectedLocale) Is(err error) bool {
+	var target *ErrUndetectedLocale
+	if ok := errors.As(err, &target); ok {
+		return u.Value == target.Value
+	}
+	return false
+}
+
+func newUndetectedLocaleError(value string) error {
+	return &ErrUndetectedLocale{
+		Value: value,
+	}
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDetectLocale(t *testing.T) {
+	langs := DetectLocale("martes, 15 de octubre de 2024", nil)
+	if !slices.Contains(langs, LocaleEs) || !slices.Contains(langs, LocaleEsMX) {
+		t.Errorf("expected the Spanish languages, got: %v", langs)
+	}
+
+	if slices.Contains(langs, LocaleFr) || slices.Contains(langs, LocaleEn) {
+		t.Errorf("expected no French or English languages, got: %v", langs)
+	}
+
+	langs = DetectLocale("Dienstag, 15. Oktober 2024", []string{LocaleDe, LocaleFr, LocaleEs})
+	if !slices.Equal(langs, []string{LocaleDe}) {
+		t.Errorf("expected: %v, got: %v", []string{LocaleDe}, langs)
+	}
+
+	if langs = DetectLocale("2024-10-15", nil); len(langs) != 0 {
+		t.Errorf("expected no languages, got: %v", langs)
+	}
+
+	if langs = DetectLocale("xyzzy", nil); len(langs) != 0 {
+		t.Errorf("expected no languages, got: %v", langs)
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	want := time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+	result, err := ParseAny("Monday, 2 January 2006", "mardi, 15 octobre 2024", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if !result.Time.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, result.Time)
+	}
+
+	if !slices.Contains(result.Locales, LocaleFr) || !slices.Contains(result.Locales, LocaleFrCA) {
+		t.Errorf("expected the French languages, got: %v", result.Locales)
+	}
+
+	if result.Confidence != 1 || result.Ambiguous {
+		t.Errorf("expected an unambiguous full confidence result, got: %+v", result)
+	}
+
+	result, err = ParseAny("Monday, 2 January 2006", "mardi, 15 octobre 2024", []string{LocaleEs, LocaleFr})
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if !slices.Equal(result.Locales, []string{LocaleFr}) {
+		t.Errorf("expected: %v, got: %v", []string{LocaleFr}, result.Locales)
+	}
+
+	result, err = ParseAny("2006-01-02", "2024-10-15", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if !result.Time.Equal(want) || len(result.Locales) != 0 {
+		t.Errorf("expected: %v with no languages, got: %+v", want, result)
+	}
+
+	_, err = ParseAny("Monday", "xyzzy", nil)
+	if expected := newUndetectedLocaleError("xyzzy"); !errors.Is(err, expected) {
+		t.Errorf("expected error: '%v', got: '%v'", expected, err)
+	}
+
+	_, err = ParseAny("Monday", "lunes", []string{"zz"})
+	var e *ErrUnsupportedLocale
+	if !errors.As(err, &e) || e.lang != "zz" {
+		t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"zz"}, err)
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// transliterateDigits replaces the digits of the locale numbering system, and the full-width
+// digits, which are commonly used regardless of the locale numbering system, by their ASCII
+// counterparts. Values without any of those digits are returned as is, without allocating.
+func transliterateDigits(value string, locale Locale) string {
+	i := 0
+	for i < len(value) && value[i] < utf8.RuneSelf {
+		i++
+	}
+
+	if i == len(value) {
+		return value
+	}
+
+	var digits string
+	if ns, ok := locale.(NumberingSystemLocale); ok {
+		digits = numberingSystemsDigits[ns.NumberingSystem()]
+	}
+
+	// the value is only copied from its first digit to transliterate
+	for i < len(value) {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if r >= utf8.RuneSelf && digitValue(r, digits) >= 0 {
+			break
+		}
+		i += size
+	}
+
+	if i == len(value) {
+		return value
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(value))
+	sb.WriteString(value[:i])
+	for _, r := range value[i:] {
+		if d := digitValue(r, digits); d >= 0 {
+			sb.WriteByte(byte('0' + d))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// digitValue returns the value of the r digit, or -1 if r is neither one of the digits,
+// nor a full-width digit.
+func digitValue(r rune, digits string) int {
+	if r >= '０' && r <= '９' {
+		return int(r - '０')
+	}
+
+	if r < utf8.RuneSelf {
+		return -1
+	}
+
+	d := 0
+	for _, digit := range digits {
+		if digit == r {
+			return d
+		}
+		d++
+	}
+
+	return -1
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTranslateNativeDigits(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		value  string
+		locale Locale
+		want   string
+	}{
+		{
+			name:   "ArabicIndic",
+			layout: "Monday، 02 January 2006",
+			value:  "الخميس، ٢٧ أكتوبر ١٩٨٨",
+			locale: newTestLocale(LocaleArEG, map[int][]string{numberingSystemField: {"arab"}}),
+			want:   "Thursday، 27 October 1988",
+		},
+		{
+			name:   "ExtendedArabicIndic",
+			layout: "02 January 2006 15:04",
+			value:  "۲۷ اکتبر ۱۹۸۸ ۲۳:۵۳",
+			locale: newTestLocale(LocaleFa, map[int][]string{numberingSystemField: {"arabext"}}),
+			want:   "27 October 1988 23:53",
+		},
+		{
+			name:   "Devanagari",
+			layout: "02 January 2006",
+			value:  "२७ अक्टूबर १९८८",
+			locale: newTestLocale(LocaleHi, map[int][]string{numberingSystemField: {"deva"}}),
+			want:   "27 October 1988",
+		},
+		{
+			name:   "FullWidth",
+			layout: "2006年January02日",
+			value:  "１９８８年10月２７日",
+			locale: newTestLocale(LocaleJa, map[int][]string{numberingSystemField: {"latn"}}),
+			want:   "1988年October27日",
+		},
+		{
+			name:   "OtherNumberingSystemDigits",
+			layout: "January 2006",
+			value:  "أكتوبر ١٩٨٨",
+			locale: newTestLocale(LocaleArEG, map[int][]string{numberingSystemField: {"latn"}}),
+			want:   "October ١٩٨٨",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateWithLocale(tt.layout, tt.value, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected value %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseNativeDigits(t *testing.T) {
+	locale := newTestLocale(LocaleArEG, map[int][]string{numberingSystemField: {"arab"}})
+	got, err := ParseInLocationWithLocale("02 January 2006 15:04", "٢٧ أكتوبر ١٩٨٨ ٢٣:٥٣", defaultLocation, locale)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	want := time.Date(1988, time.October, 27, 23, 53, 0, 0, defaultLocation)
+	if !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestParseNativeDigitsLocales(t *testing.T) {
+	tests := []struct {
+		lang   string
+		layout string
+		value  string
+		want   time.Time
+	}{
+		{lang: LocaleArEG, layout: "02/01/2006 15:04", value: "٢٧/١٠/١٩٨٨ ٢٣:٥٣", want: time.Date(1988, time.October, 27, 23, 53, 0, 0, time.UTC)},
+		{lang: LocaleFa, layout: "2006/01/02 15:04", value: "۱۹۸۸/۱۰/۲۷ ۲۳:۵۳", want: time.Date(1988, time.October, 27, 23, 53, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, numberingSystemField)
+
+			got, err := Parse(tt.layout, tt.value, tt.lang)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v ('%v')", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestTransliterateDigitsAllocs(t *testing.T) {
+	locale := newTestLocale(LocaleArEG, map[int][]string{numberingSystemField: {"arab"}})
+
+	// the values with no digits to transliterate are returned as is, without allocating
+	for _, value := range []string{"27 octubre 1988", "27 días de octubre de 1988", "27 أكتوبر 1988"} {
+		var got string
+		allocs := testing.AllocsPerRun(10, func() {
+			got = transliterateDigits(value, locale)
+		})
+
+		if got != value || allocs != 0 {
+			t.Errorf("expected value %q with no allocations, got: %q (%v allocations)", value, got, allocs)
+		}
+	}
+
+	if got := transliterateDigits("٢٧ أكتوبر ١٩٨٨", locale); got != "27 أكتوبر 1988" {
+		t.Errorf("expected value \"27 أكتوبر 1988\", got: %q", got)
+	}
+}
+
+func TestLocaleNumberingSystem(t *testing.T) {
+	locale := genericLocale{lang: LocaleEn}
+	if locale.NumberingSystem() != "latn" {
+		t.Errorf("expected numbering system \"latn\", got: %q", locale.NumberingSystem())
+	}
+
+	locale.table[numberingSystemField] = []string{"arab"}
+	if locale.NumberingSystem() != "arab" {
+		t.Errorf("expected numbering system \"arab\", got: %q", locale.NumberingSystem())
+	}
+}
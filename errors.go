@@ -0,0 +1,256 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrParse indicates that a localized value could not be parsed, locating the layout element
+// it does not match in the value. It wraps the error reporting the mismatch, such as
+// ErrLayoutMismatch, ErrAmbiguousValue, or the time package *time.ParseError, whose offsets
+// refer to the translated value instead.
+type ErrParse struct {
+	// Value is the localized value.
+	Value string
+	// LayoutElem is the layout element the value does not match, or empty if the value
+	// has extra text.
+	LayoutElem string
+	// Offset and RuneOffset are the byte and rune offsets of the mismatch in the value,
+	// or -1 if it is unknown.
+	Offset     int
+	RuneOffset int
+	// Candidates holds the locale names the layout element accepts, if it is a names one,
+	// and Suggestion the closest one to the value at the offset, if any is close enough.
+	Candidates []string
+	Suggestion string
+	// Err is the error reporting the mismatch.
+	Err error
+}
+
+func (e *ErrParse) Error() string {
+	var sb strings.Builder
+
+	// the time package errors describe the translated value, and the mismatches are
+	// described by the located layout element
+	var ambiguous *ErrAmbiguousValue
+	var pe *time.ParseError
+	switch {
+	case errors.As(e.Err, &ambiguous):
+		sb.WriteString(e.Err.Error())
+	case errors.As(e.Err, &pe) && pe.Message != "":
+		fmt.Fprintf(&sb, `value "%s"%s`, e.Value, pe.Message)
+	default:
+		fmt.Fprintf(&sb, `value "%s" does not match the layout element "%s"`, e.Value, e.LayoutElem)
+	}
+
+	if e.Offset >= 0 {
+		fmt.Fprintf(&sb, " at offset %d", e.Offset)
+	}
+
+	if e.Suggestion != "" {
+		fmt.Fprintf(&sb, `, did you mean "%s"?`, e.Suggestion)
+	}
+
+	return sb.String()
+}
+
+func (e *ErrParse) Unwrap() error {
+	return e.Err
+}
+
+// newParseError returns the ErrParse wrapping the err returned by parsing the value, locating
+// the mismatch by parsing the value again with parseNative. Other errors, such as the
+// unsupported layout elements ones, are returned as is.
+func newParseError(layout string, elems []layoutElem, value string, locale Locale, opts *Options, err error) error {
+	var mismatch *ErrLayoutMismatch
+	var ambiguous *ErrAmbiguousValue
+	var pe *time.ParseError
+	e := &ErrParse{Value: value, Offset: -1, RuneOffset: -1, Err: err}
+	switch {
+	case errors.As(err, &mismatch):
+		e.LayoutElem = mismatch.LayoutElem
+	case errors.As(err, &ambiguous):
+		e.LayoutElem = ambiguous.LayoutElem
+	case errors.As(err, &pe):
+		e.LayoutElem = pe.LayoutElem
+	default:
+		return err
+	}
+
+	transliterated := transliterateDigits(value, locale)
+	p := newNativeParser()
+	p.diagnose = true
+	if p.read(layout, elems, transliterated, locale, opts) {
+		p.time(nil)
+	}
+
+	if !p.failed || p.failedAt < 0 {
+		return e
+	}
+
+	e.LayoutElem = layout[p.failedAt : p.failedAt+p.failedLen]
+	e.Offset = untransliteratedOffset(value, len(transliterated)-len(p.failedValue), locale)
+	e.RuneOffset = utf8.RuneCountInString(value[:e.Offset])
+
+	if p.failedAt == len(layout) {
+		return e
+	}
+
+	var elem *layoutElem
+	if elems != nil {
+		elem = &elems[p.failedAt]
+	} else if startsLayoutElem(layout[p.failedAt]) {
+		elem = &layoutElem{}
+		setLayoutElem(elem, layout, p.failedAt, locale, opts, isEraLayout(layout, locale))
+	}
+
+	if elem != nil && elem.err == nil && elem.kind != timeZoneLayoutElem {
+		e.Candidates = layoutElemCandidates(elem)
+		if ambiguous == nil {
+			e.Suggestion = closestCandidate(e.Candidates, value[e.Offset:])
+		}
+	}
+
+	return e
+}
+
+// untransliteratedOffset returns the value offset matching the offset of the value with its
+// digits transliterated by transliterateDigits.
+func untransliteratedOffset(value string, offset int, locale Locale) int {
+	var digits string
+	if ns, ok := locale.(NumberingSystemLocale); ok {
+		digits = numberingSystemsDigits[ns.NumberingSystem()]
+	}
+
+	i, translated := 0, 0
+	for i < len(value) && translated < offset {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if r >= utf8.RuneSelf && digitValue(r, digits) >= 0 {
+			translated++
+		} else {
+			translated += utf8.RuneLen(r)
+		}
+		i += size
+	}
+
+	return i
+}
+
+// layoutElemCandidates returns the distinct non-empty names the layout element matches.
+func layoutElemCandidates(elem *layoutElem) []string {
+	tabs := elem.lookupTabs()
+	if elem.kind == dayPeriodLayoutElem {
+		tabs = append([][]string{elem.lookupTab}, tabs...)
+	}
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, tab := range tabs {
+		for _, name := range tab {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				candidates = append(candidates, name)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// closestCandidate returns the candidate closest to the value start, ignoring case, if its
+// edit distance is at most a third of its length, or an empty string otherwise.
+func closestCandidate(candidates []string, value string) string {
+	var closest string
+	closestDistance := -1
+	for _, candidate := range candidates {
+		name := foldRunes(candidate)
+		if len(name) < 3 {
+			continue
+		}
+
+		// the value might be missing or having extra characters
+		distance := -1
+		for _, n := range []int{len(name), len(name) - 1, len(name) + 1} {
+			d := editDistance(name, foldRunes(runesPrefix(value, n)))
+			if distance < 0 || d < distance {
+				distance = d
+			}
+		}
+
+		if distance*3 <= len(name) && (closestDistance < 0 || distance < closestDistance) {
+			closest, closestDistance = candidate, distance
+		}
+	}
+
+	return closest
+}
+
+// runesPrefix returns the prefix of the value with n runes at most.
+func runesPrefix(value string, n int) string {
+	i := 0
+	for ; n > 0 && i < len(value); n-- {
+		_, size := utf8.DecodeRuneInString(value[i:])
+		i += size
+	}
+	return value[:i]
+}
+
+// foldRunes returns the case-folded runes of s.
+func foldRunes(s string) []rune {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		runes = append(runes, foldRune(r))
+	}
+	return runes
+}
+
+// editDistance returns the optimal string alignment distance of a and b, which is the
+// number of runes insertions, deletions, substitutions, and adjacent transpositions
+// turning a into b.
+func editDistance(a, b []rune) int {
+	// only the last three rows of the distances matrix are kept
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,248 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestErrParse(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	arab := newTestLocale(LocaleArEG, map[int][]string{numberingSystemField: {"arab"}})
+
+	tests := []struct {
+		name       string
+		layout     string
+		value      string
+		locale     Locale
+		layoutElem string
+		offset     int
+		runeOffset int
+		suggestion string
+		message    string
+	}{
+		{
+			name:       "Suggestion",
+			layout:     "Monday, 2 January 2006",
+			value:      "jueves, 27 octubr 1988",
+			locale:     es,
+			layoutElem: "January",
+			offset:     11,
+			runeOffset: 11,
+			suggestion: "octubre",
+			message:    `value "jueves, 27 octubr 1988" does not match the layout element "January" at offset 11, did you mean "octubre"?`,
+		},
+		{
+			name:       "Transposition",
+			layout:     "Monday, 2 January 2006",
+			value:      "miércoles, 26 ocutbre 1988",
+			locale:     es,
+			layoutElem: "January",
+			offset:     15,
+			runeOffset: 14,
+			suggestion: "octubre",
+			message:    `value "miércoles, 26 ocutbre 1988" does not match the layout element "January" at offset 15, did you mean "octubre"?`,
+		},
+		{
+			name:       "NoSuggestion",
+			layout:     "Monday, 2 January 2006",
+			value:      "jueves, 27 abc 1988",
+			locale:     es,
+			layoutElem: "January",
+			offset:     11,
+			runeOffset: 11,
+			message:    `value "jueves, 27 abc 1988" does not match the layout element "January" at offset 11`,
+		},
+		{
+			name:       "DayOutOfRange",
+			layout:     "Monday, 2 January 2006",
+			value:      "jueves, 32 octubre 1988",
+			locale:     es,
+			layoutElem: "2",
+			offset:     8,
+			runeOffset: 8,
+			message:    `value "jueves, 32 octubre 1988": day out of range at offset 8`,
+		},
+		{
+			name:       "ExtraText",
+			layout:     "Monday, 2 January 2006",
+			value:      "jueves, 27 octubre 1988 11:53",
+			locale:     es,
+			offset:     23,
+			runeOffset: 23,
+			message:    `value "jueves, 27 octubre 1988 11:53": extra text: " 11:53" at offset 23`,
+		},
+		{
+			name:       "Numbers",
+			layout:     "2 January 2006 15:04",
+			value:      "27 octubre 1988 11:x3",
+			locale:     es,
+			layoutElem: "04",
+			offset:     19,
+			runeOffset: 19,
+			message:    `value "27 octubre 1988 11:x3" does not match the layout element "04" at offset 19`,
+		},
+		{
+			name:       "NativeDigits",
+			layout:     "Monday، 02 January 2006",
+			value:      "الخميس، ٢٧ أكتوبب ١٩٨٨",
+			locale:     arab,
+			layoutElem: "January",
+			offset:     20,
+			runeOffset: 11,
+			suggestion: "أكتوبر",
+			message:    `value "الخميس، ٢٧ أكتوبب ١٩٨٨" does not match the layout element "January" at offset 20, did you mean "أكتوبر"?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWithLocale(tt.layout, tt.value, tt.locale)
+
+			var e *ErrParse
+			if !errors.As(err, &e) {
+				t.Fatalf("expected an ErrParse error, got: '%v'", err)
+			}
+
+			if e.Value != tt.value || e.LayoutElem != tt.layoutElem || e.Offset != tt.offset || e.RuneOffset != tt.runeOffset || e.Suggestion != tt.suggestion {
+				t.Errorf("expected: '%s' '%s' %d %d '%s', got: '%s' '%s' %d %d '%s'",
+					tt.value, tt.layoutElem, tt.offset, tt.runeOffset, tt.suggestion,
+					e.Value, e.LayoutElem, e.Offset, e.RuneOffset, e.Suggestion)
+			}
+
+			if e.Suggestion != "" && !slices.Contains(e.Candidates, e.Suggestion) {
+				t.Errorf("expected the candidates %v to contain '%s'", e.Candidates, e.Suggestion)
+			}
+
+			if err.Error() != tt.message {
+				t.Errorf("expected message: '%s', got: '%s'", tt.message, err.Error())
+			}
+		})
+	}
+}
+
+func TestErrParseCandidates(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+
+	_, err := ParseWithLocale("Mon 2 Jan 2006", "jue 27 abc 1988", es)
+	var e *ErrParse
+	if !errors.As(err, &e) {
+		t.Fatalf("expected an ErrParse error, got: '%v'", err)
+	}
+
+	for _, name := range []string{"ene", "oct", "sept"} {
+		if !slices.Contains(e.Candidates, name) {
+			t.Errorf("expected the candidates %v to contain '%s'", e.Candidates, name)
+		}
+	}
+
+	if slices.Contains(e.Candidates, "octubre") || slices.Contains(e.Candidates, "") {
+		t.Errorf("expected only the short months names, got: %v", e.Candidates)
+	}
+
+	_, err = ParseWithLocale("3:04 PM", "3:04 xx", es)
+	if !errors.As(err, &e) || !slices.Equal(e.Candidates, []string{"a.m.", "p.m."}) {
+		t.Errorf("expected the day periods candidates, got: '%v'", err)
+	}
+}
+
+func TestErrParseUnwrap(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+
+	// the wrapped errors are still reported
+	_, err := ParseWithLocale("Monday Jan 2 2006", "lunes abc 27 1988", es)
+	expected := newLayoutMismatchError("Jan", "lunes abc 27 1988")
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error: '%v', got: '%v'", expected, err)
+	}
+
+	_, err = ParseWithLocale("2 Jan 2006", "32 oct 1988", es)
+	var pe *time.ParseError
+	if !errors.As(err, &pe) {
+		t.Errorf("expected a time.ParseError error, got: '%v'", err)
+	}
+
+	narrow := newTestLocale(LocaleDe, narrowTestFields)
+	_, err = ParseWithOptions("Mon 2 Jan 2006", "M 27 O 1988", narrow, Options{NarrowNames: true})
+	var e *ErrParse
+	var ambiguous *ErrAmbiguousValue
+	if !errors.As(err, &e) || !errors.As(err, &ambiguous) || e.LayoutElem != "Mon" || e.Offset != 0 || e.Suggestion != "" {
+		t.Errorf("expected an ambiguous ErrParse error, got: '%v'", err)
+	}
+
+	// the errors not related to the value are not wrapped
+	longEras := newTestLocale(LocaleEs, map[int][]string{longEraNamesField: {"antes de Cristo", "después de Cristo"}, shortEraNamesField: nil})
+	_, err = ParseWithLocale("2 Jan 2006 AD", "27 oct 1988 d. C.", longEras)
+	if errors.As(err, &e) {
+		t.Errorf("expected no ErrParse error, got: '%v'", err)
+	}
+}
+
+func TestErrParseFunctions(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	layout, _ := CompileLayout("Monday, 2 January 2006", es)
+	value := "jueves, 27 octubr 1988"
+
+	parseFns := map[string]func() (time.Time, error){
+		"ParseInLocationWithLocale": func() (time.Time, error) {
+			return ParseInLocationWithLocale("Monday, 2 January 2006", value, time.UTC, es)
+		},
+		"ParseBytes": func() (time.Time, error) {
+			return ParseBytes("Monday, 2 January 2006", []byte(value), es)
+		},
+		"Layout.Parse": func() (time.Time, error) {
+			return layout.Parse(value)
+		},
+		"Layout.ParseBytes": func() (time.Time, error) {
+			return layout.ParseBytes([]byte(value))
+		},
+	}
+
+	for name, fn := range parseFns {
+		_, err := fn()
+		var e *ErrParse
+		if !errors.As(err, &e) || e.Offset != 11 || e.Suggestion != "octubre" {
+			t.Errorf("%s: expected an ErrParse error at offset 11, got: '%v'", name, err)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "octubre", b: "octubre", want: 0},
+		{a: "octubre", b: "octubr", want: 1},
+		{a: "octubre", b: "ocutbre", want: 1},
+		{a: "octubre", b: "octobre", want: 1},
+		{a: "octubre", b: "", want: 7},
+		{a: "enero", b: "junio", want: 4},
+		{a: "mañana", b: "manana", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("%s %s: expected: %d, got: %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// excelLCIDs maps the Windows locales identifiers (LCID) used by the spreadsheets format
+// codes locale prefixes (e.g. "[$-407]") to the lunes locales.
+var excelLCIDs = map[uint16]string{
+	0x0401: LocaleArSA,
+	0x0402: LocaleBgBG,
+	0x0403: LocaleCaES,
+	0x0404: LocaleZhHantTW,
+	0x0405: LocaleCsCZ,
+	0x0406: LocaleDaDK,
+	0x0407: LocaleDeDE,
+	0x0408: LocaleElGR,
+	0x0409: LocaleEnUS,
+	0x040A: LocaleEsES,
+	0x040B: LocaleFiFI,
+	0x040C: LocaleFrFR,
+	0x040D: LocaleHeIL,
+	0x040E: LocaleHuHU,
+	0x040F: LocaleIsIS,
+	0x0410: LocaleItIT,
+	0x0411: LocaleJaJP,
+	0x0412: LocaleKoKR,
+	0x0413: LocaleNlNL,
+	0x0414: LocaleNo,
+	0x0415: LocalePlPL,
+	0x0416: LocalePtBR,
+	0x0418: LocaleRoRO,
+	0x0419: LocaleRuRU,
+	0x041A: LocaleHrHR,
+	0x041B: LocaleSkSK,
+	0x041C: LocaleSqAL,
+	0x041D: LocaleSvSE,
+	0x041E: LocaleThTH,
+	0x041F: LocaleTrTR,
+	0x0420: LocaleUrPK,
+	0x0421: LocaleIdID,
+	0x0422: LocaleUkUA,
+	0x0423: LocaleBeBY,
+	0x0424: LocaleSlSI,
+	0x0425: LocaleEtEE,
+	0x0426: LocaleLvLV,
+	0x0427: LocaleLtLT,
+	0x0429: LocaleFaIR,
+	0x042A: LocaleViVN,
+	0x042B: LocaleHyAM,
+	0x042C: LocaleAzLatnAZ,
+	0x042D: LocaleEuES,
+	0x042F: LocaleMkMK,
+	0x0436: LocaleAfZA,
+	0x0437: LocaleKaGE,
+	0x0438: LocaleFoFO,
+	0x0439: LocaleHiIN,
+	0x043E: LocaleMsMY,
+	0x043F: LocaleKkKZ,
+	0x0441: LocaleSwKE,
+	0x0443: LocaleUzLatnUZ,
+	0x0445: LocaleBnIN,
+	0x0447: LocaleGuIN,
+	0x0449: LocaleTaIN,
+	0x044A: LocaleTeIN,
+	0x044B: LocaleKnIN,
+	0x044C: LocaleMlIN,
+	0x044E: LocaleMrIN,
+	0x0456: LocaleGlES,
+	0x0804: LocaleZhHansCN,
+	0x0807: LocaleDeCH,
+	0x0809: LocaleEnGB,
+	0x080A: LocaleEsMX,
+	0x080C: LocaleFrBE,
+	0x0810: LocaleItCH,
+	0x0813: LocaleNlBE,
+	0x0814: LocaleNnNO,
+	0x0816: LocalePtPT,
+	0x081D: LocaleSvFI,
+	0x0C04: LocaleZhHantHK,
+	0x0C07: LocaleDeAT,
+	0x0C09: LocaleEnAU,
+	0x0C0A: LocaleEsES,
+	0x0C0C: LocaleFrCA,
+	0x1004: LocaleZhHansSG,
+	0x1007: LocaleDeLU,
+	0x1009: LocaleEnCA,
+	0x100C: LocaleFrCH,
+	0x1407: LocaleDeLI,
+	0x1409: LocaleEnNZ,
+	0x140C: LocaleFrLU,
+	0x1809: LocaleEnIE,
+	0x1C09: LocaleEnZA,
+	0x2C0A: LocaleEsAR,
+	0x240A: LocaleEsCO,
+	0x280A: LocaleEsPE,
+	0x340A: LocaleEsCL,
+	0x4009: LocaleEnIN,
+	0x4809: LocaleEnSG,
+}
+
+// excelSystemLCIDs holds the spreadsheets system long date (F800) and time (F400) formats
+// identifiers, which are not locales.
+var excelSystemLCIDs = map[uint16]bool{0xF400: true, 0xF800: true}
+
+// excelToken is a spreadsheet format code element: a date or time field (e.g. "mmm"), a day
+// period (e.g. "AM/PM"), a fractional second (e.g. ".000"), or a literal.
+type excelToken struct {
+	field   string
+	literal string
+}
+
+// ExcelToLayout converts the spreadsheet (Excel, LibreOffice) date format code (e.g.
+// "[$-407]dddd, d. mmmm yyyy") to the equivalent layout (e.g. "Monday, 2. January 2006"),
+// and returns the language of its locale prefix, or an empty string if it has none. Only
+// the first section of the format code is used. Colors and conditions are ignored.
+//
+// As spreadsheets do, the "m" and "mm" codes are minutes if they immediately follow an
+// hour code, or are immediately followed by a seconds code, and months otherwise. The hours
+// use the 12-hour clock if the format code includes the AM/PM code.
+//
+// Codes with no layout equivalent, such as the elapsed times (e.g. "[h]"), the months
+// initials ("mmmmm"), the A/P day periods, or the eras, result in an ErrUnsupportedPatternElem
+// error, and unknown locale identifiers in an ErrUnsupportedLocale error.
+func ExcelToLayout(code string) (string, string, error) {
+	tokens, lang, err := excelTokens(code)
+	if err != nil {
+		return "", "", err
+	}
+
+	var twelveHours bool
+	for _, token := range tokens {
+		if token.field == "AM/PM" || token.field == "am/pm" {
+			twelveHours = true
+		}
+	}
+
+	var sb strings.Builder
+	for i, token := range tokens {
+		if token.field == "" {
+			if containsLayoutElem(sb.String(), token.literal) {
+				return "", "", newUnsupportedPatternElemError(token.literal, code)
+			}
+
+			sb.WriteString(token.literal)
+			continue
+		}
+
+		elem := excelLayoutElem(tokens, i, twelveHours)
+		if elem == "" {
+			return "", "", newUnsupportedPatternElemError(token.field, code)
+		}
+
+		sb.WriteString(elem)
+	}
+
+	return sb.String(), lang, nil
+}
+
+// excelTokens splits the first section of the format code into tokens, and returns the
+// language of its locale prefix.
+func excelTokens(code string) ([]excelToken, string, error) {
+	var tokens []excelToken
+	var lang string
+	literal := func(s string) {
+		tokens = append(tokens, excelToken{literal: s})
+	}
+
+	for i := 0; i < len(code); {
+		c := code[i]
+		switch {
+		case c == ';':
+			// the following sections are used for negative numbers, zeros, and texts
+			return tokens, lang, nil
+		case c == '"':
+			end := strings.IndexByte(code[i+1:], '"')
+			if end < 0 {
+				return nil, "", newUnsupportedPatternElemError(code[i:], code)
+			}
+			literal(code[i+1 : i+1+end])
+			i += end + 2
+		case c == '\\' && i+1 < len(code):
+			literal(code[i+1 : i+2])
+			i += 2
+		case c == '_' && i+1 < len(code):
+			// the width of the following character
+			literal(" ")
+			i += 2
+		case c == '*' && i+1 < len(code):
+			// the following character fills the cell
+			i += 2
+		case c == '[':
+			end := strings.IndexByte(code[i:], ']')
+			if end < 0 {
+				return nil, "", newUnsupportedPatternElemError(code[i:], code)
+			}
+
+			content := code[i+1 : i+end]
+			switch lower := strings.ToLower(content); {
+			case strings.HasPrefix(content, "$"):
+				symbol, id, _ := strings.Cut(content[1:], "-")
+				if symbol != "" {
+					literal(symbol)
+				}
+
+				if id != "" {
+					var err error
+					if lang, err = excelLCIDLanguage(id); err != nil {
+						return nil, "", err
+					}
+				}
+			case strings.Trim(lower, "hms") == "":
+				// elapsed times
+				return nil, "", newUnsupportedPatternElemError(code[i:i+end+1], code)
+			}
+			// colors and conditions are ignored
+			i += end + 1
+		case strings.HasPrefix(strings.ToLower(code[i:]), "am/pm"):
+			tokens = append(tokens, excelToken{field: code[i : i+5]})
+			i += 5
+		case strings.HasPrefix(strings.ToLower(code[i:]), "a/p"):
+			tokens = append(tokens, excelToken{field: code[i : i+3]})
+			i += 3
+		case c == '.' && i+1 < len(code) && code[i+1] == '0':
+			end := i + 1
+			for end < len(code) && code[end] == '0' {
+				end++
+			}
+			tokens = append(tokens, excelToken{field: code[i:end]})
+			i = end
+		case isPatternLetter(c) || (c >= '0' && c <= '9') || strings.IndexByte("@#?%", c) >= 0:
+			end := i + 1
+			for end < len(code) && (code[end]|0x20) == (c|0x20) {
+				end++
+			}
+			tokens = append(tokens, excelToken{field: strings.ToLower(code[i:end])})
+			i = end
+		default:
+			literal(code[i : i+1])
+			i++
+		}
+	}
+
+	return tokens, lang, nil
+}
+
+// excelLCIDLanguage returns the lunes language of the hexadecimal locale identifier,
+// ignoring its calendar and numbering system bytes.
+func excelLCIDLanguage(id string) (string, error) {
+	// system date and time formats (e.g. "x-sysdate")
+	if strings.HasPrefix(id, "x-") {
+		return "", nil
+	}
+
+	n, err := strconv.ParseUint(id, 16, 32)
+	if err != nil {
+		return "", &ErrUnsupportedLocale{"[$-" + id + "]"}
+	}
+
+	lcid := uint16(n & 0xFFFF)
+	if excelSystemLCIDs[lcid] {
+		return "", nil
+	}
+
+	lang, ok := excelLCIDs[lcid]
+	if !ok {
+		return "", &ErrUnsupportedLocale{"[$-" + id + "]"}
+	}
+
+	return lang, nil
+}
+
+// excelLayoutElem returns the layout element equivalent to the field token, or an empty
+// string if there is none.
+func excelLayoutElem(tokens []excelToken, index int, twelveHours bool) string {
+	field := tokens[index].field
+	count := len(field)
+	switch field[0] {
+	case 'y':
+		if count <= 2 {
+			return "06"
+		}
+		return "2006"
+	case 'm':
+		if count <= 2 && isExcelMinute(tokens, index) {
+			return [...]string{"4", "04"}[count-1]
+		}
+		if count <= 4 {
+			return [...]string{"1", "01", "Jan", "January"}[count-1]
+		}
+	case 'd':
+		return [...]string{"2", "02", "Mon", "Monday"}[min(count, 4)-1]
+	case 'h':
+		if twelveHours {
+			return [...]string{"3", "03"}[min(count, 2)-1]
+		}
+		return "15"
+	case 's':
+		return [...]string{"5", "05"}[min(count, 2)-1]
+	case '.':
+		if index > 0 && tokens[index-1].field != "" && tokens[index-1].field[0] == 's' {
+			return field
+		}
+	case 'A':
+		if field == "AM/PM" {
+			return "PM"
+		}
+	case 'a':
+		if field == "am/pm" {
+			return "pm"
+		}
+	}
+
+	return ""
+}
+
+// isExcelMinute reports whether the "m" or "mm" token at the index is a minute, which
+// is the case if it follows an hour field, or if it is followed by a seconds field.
+func isExcelMinute(tokens []excelToken, index int) bool {
+	for i := index - 1; i >= 0; i-- {
+		if tokens[i].field != "" {
+			if tokens[i].field[0] == 'h' {
+				return true
+			}
+			break
+		}
+	}
+
+	for i := index + 1; i < len(tokens); i++ {
+		if tokens[i].field != "" {
+			return tokens[i].field[0] == 's'
+		}
+	}
+
+	return false
+}
+
+// ParseExcel parses a formatted string in foreign language using the spreadsheet date
+// format code (e.g. "[$-40C]d mmm yy"), which is converted to the equivalent layout by
+// [ExcelToLayout], and returns the [time.Time] value it represents. The language of the
+// format code locale prefix is used if present, otherwise, the lang argument is used.
+// See [Parse] for more details.
+func ParseExcel(code string, value string, lang string) (time.Time, error) {
+	layout, codeLang, err := ExcelToLayout(code)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if codeLang != "" {
+		lang = codeLang
+	}
+
+	return Parse(layout, value, lang)
+}
+
+// ParseExcelWithLocale is like ParseExcel, but instead of receiving a BCP 47 language tag
+// argument, it receives a built [lunes.Locale], which is used regardless of the format code
+// locale prefix.
+func ParseExcelWithLocale(code string, value string, locale Locale) (time.Time, error) {
+	layout, _, err := ExcelToLayout(code)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseWithLocale(layout, value, locale)
+}
+
+// FormatExcel returns a textual representation of the time value formatted according to
+// the spreadsheet date format code (e.g. "[$-407]dddd, d. mmmm yyyy"), using the names of
+// the format code locale prefix language if present, otherwise, of the lang argument.
+// See [Format] for more details.
+func FormatExcel(t time.Time, code string, lang string) (string, error) {
+	layout, codeLang, err := ExcelToLayout(code)
+	if err != nil {
+		return "", err
+	}
+
+	if codeLang != "" {
+		lang = codeLang
+	}
+
+	return Format(t, layout, lang)
+}
+
+// FormatExcelWithLocale is like FormatExcel, but instead of receiving a BCP 47 language tag
+// argument, it receives a built [lunes.Locale], which is used regardless of the format code
+// locale prefix.
+func FormatExcelWithLocale(t time.Time, code string, locale Locale) (string, error) {
+	layout, _, err := ExcelToLayout(code)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatWithLocale(t, layout, locale)
+}
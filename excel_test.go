@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExcelToLayout(t *testing.T) {
+	tests := []struct {
+		code   string
+		layout string
+		lang   string
+	}{
+		{code: "[$-407]dddd, d. mmmm yyyy", layout: "Monday, 2. January 2006", lang: LocaleDeDE},
+		{code: "[$-40C]d mmm yy", layout: "2 Jan 06", lang: LocaleFrFR},
+		{code: "[$-1010409]m/d/yyyy", layout: "1/2/2006", lang: LocaleEnUS},
+		{code: "[$-F800]dddd, mmmm dd, yyyy", layout: "Monday, January 02, 2006"},
+		{code: "[$-x-sysdate]dddd", layout: "Monday"},
+		{code: "yyyy-mm-dd hh:mm:ss", layout: "2006-01-02 15:04:05"},
+		{code: "YYYY-MM-DD", layout: "2006-01-02"},
+		{code: "m/d/yy h:mm AM/PM", layout: "1/2/06 3:04 PM"},
+		{code: "hh:mm am/pm", layout: "03:04 pm"},
+		{code: "mm:ss.000", layout: "04:05.000"},
+		{code: "h:mm", layout: "15:04"},
+		{code: "mmm-yy", layout: "Jan-06"},
+		{code: "[Red]dd/mm/yyyy;@", layout: "02/01/2006"},
+		{code: "dd\\-mmm\\-yyyy", layout: "02-Jan-2006"},
+		{code: "dd \"de\" mmmm", layout: "02 de January"},
+		{code: "d_)mmm*-", layout: "2 Jan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			layout, lang, err := ExcelToLayout(tt.code)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if layout != tt.layout {
+				t.Errorf("expected layout: '%s', got: '%s'", tt.layout, layout)
+			}
+
+			if lang != tt.lang {
+				t.Errorf("expected language: '%s', got: '%s'", tt.lang, lang)
+			}
+		})
+	}
+
+	unsupported := []struct {
+		code string
+		elem string
+	}{
+		{code: "[h]:mm:ss", elem: "[h]"},
+		{code: "mmmmm yyyy", elem: "mmmmm"},
+		{code: "h:mm A/P", elem: "A/P"},
+		{code: "0.00", elem: "0"},
+		{code: "dd.00", elem: ".00"},
+		{code: "ge.m.d", elem: "g"},
+		{code: "dd \"Mon\"", elem: "Mon"},
+	}
+
+	for _, tt := range unsupported {
+		t.Run("Unsupported "+tt.code, func(t *testing.T) {
+			_, _, err := ExcelToLayout(tt.code)
+			expected := newUnsupportedPatternElemError(tt.elem, tt.code)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+
+	t.Run("UnsupportedLCID", func(t *testing.T) {
+		_, _, err := ExcelToLayout("[$-7F]dd")
+		var e *ErrUnsupportedLocale
+		if !errors.As(err, &e) || e.lang != "[$-7F]" {
+			t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"[$-7F]"}, err)
+		}
+	})
+}
+
+func TestExcelLCIDs(t *testing.T) {
+	for lcid, lang := range excelLCIDs {
+		if _, ok := getTable(lang); !ok {
+			t.Errorf("expected a table for the LCID %X language: %s", lcid, lang)
+		}
+	}
+}
+
+func TestParseExcel(t *testing.T) {
+	want := time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+	got, err := ParseExcel("[$-407]dddd, d. mmmm yyyy", "Dienstag, 15. Oktober 2024", LocaleEn)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+
+	got, err = ParseExcel("d mmmm yyyy", "15 octobre 2024", LocaleFr)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+
+	es, _ := NewDefaultLocale(LocaleEs)
+	got, err = ParseExcelWithLocale("[$-407]d mmmm yyyy", "15 octubre 2024", es)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestFormatExcel(t *testing.T) {
+	value := time.Date(2024, time.October, 15, 21, 41, 30, 0, time.UTC)
+
+	got, err := FormatExcel(value, "[$-40C]dddd d mmmm yyyy hh:mm", LocaleEn)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if want := "mardi 15 octobre 2024 21:41"; got != want {
+		t.Errorf("expected: '%s', got: '%s'", want, got)
+	}
+
+	es, _ := NewDefaultLocale(LocaleEs)
+	got, err = FormatExcelWithLocale(value, "[$-40C]ddd d mmm h:mm AM/PM", es)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if want := "mar 15 oct 9:41 p.m."; got != want {
+		t.Errorf("expected: '%s', got: '%s'", want, got)
+	}
+}
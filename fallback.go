@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import "slices"
+
+// stdLocale is the locale of the English names used by the time package, matched by the
+// EnglishFallback option.
+type stdLocale struct{}
+
+func (stdLocale) Language() string          { return LocaleEn }
+func (stdLocale) LongDayNames() []string    { return longDayNamesStd }
+func (stdLocale) ShortDayNames() []string   { return shortDayNamesStd }
+func (stdLocale) LongMonthNames() []string  { return longMonthNamesStd }
+func (stdLocale) ShortMonthNames() []string { return shortMonthNamesStd }
+func (stdLocale) DayPeriods() []string      { return dayPeriodsStdUpper }
+func (stdLocale) LongEraNames() []string    { return longErasStd }
+func (stdLocale) ShortEraNames() []string   { return shortErasStd }
+func (stdLocale) NarrowEraNames() []string  { return nil }
+
+// fallbackLayoutElems returns the layout element starting at the layout offset for each
+// fallback locale supporting it, in the order they are matched.
+func fallbackLayoutElems(layout string, layoutOffset int, opts *Options, eraLayout bool) []layoutElem {
+	locales := opts.FallbackLocales
+	if opts.EnglishFallback {
+		locales = append(slices.Clip(locales), stdLocale{})
+	}
+
+	// the fallback locales have no fallbacks themselves
+	fallbackOpts := *opts
+	fallbackOpts.FallbackLocales, fallbackOpts.EnglishFallback = nil, false
+
+	elems := make([]layoutElem, 0, len(locales))
+	for _, locale := range locales {
+		var elem layoutElem
+		setLayoutElem(&elem, layout, layoutOffset, locale, &fallbackOpts, eraLayout)
+		if elem.err == nil {
+			elem.language = locale.Language()
+			elems = append(elems, elem)
+		}
+	}
+
+	return elems
+}
+
+// appendNamesValue is like appendLayoutValue, matching the value against the element names,
+// or its AM/PM names if dayPeriods is set, and against the fallback elements ones, in order,
+// if they do not match it.
+func appendNamesValue(dst []byte, elem *layoutElem, stdTab []string, valueOffset int, value string, opts *Options, dayPeriods bool) ([]byte, int, int, error) {
+	var mismatch error
+	for i := -1; i < len(elem.fallbacks); i++ {
+		e := elem
+		if i >= 0 {
+			e = &elem.fallbacks[i]
+		}
+
+		var newOffset, index int
+		var err error
+		if dayPeriods {
+			dst, newOffset, index, err = appendLayoutValue(dst, elem.name, stdTab, valueOffset, value, opts, e.lookupTrie, e.lookupTab)
+		} else {
+			dst, newOffset, index, err = appendLayoutValue(dst, elem.name, stdTab, valueOffset, value, opts, e.trie, e.lookupTabs()...)
+		}
+
+		if index >= 0 {
+			return dst, newOffset, index, err
+		}
+
+		// the layout locale mismatch is the one reported
+		if mismatch == nil {
+			mismatch = err
+		}
+	}
+
+	return dst, valueOffset, -1, mismatch
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFallbackLocales(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	fr, _ := NewDefaultLocale(LocaleFr)
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+
+	tests := []struct {
+		layout   string
+		value    string
+		locale   Locale
+		opts     Options
+		expected time.Time
+		names    []NameMatch
+	}{
+		{
+			layout:   "Monday, 2 January 2006 3:04 PM",
+			value:    "Thursday, 27 octubre 1988 11:53 PM",
+			locale:   es,
+			opts:     Options{EnglishFallback: true},
+			expected: time.Date(1988, time.October, 27, 23, 53, 0, 0, time.UTC),
+			names: []NameMatch{
+				{LayoutElem: "Monday", Value: "Thursday", Offset: 0, Language: "en"},
+				{LayoutElem: "January", Value: "octubre", Offset: 13, Language: "es"},
+				{LayoutElem: "PM", Value: "PM", Offset: 32, Language: "en"},
+			},
+		},
+		{
+			layout:   "Mon 2 Jan 2006",
+			value:    "jeu. 27 oct 1988",
+			locale:   es,
+			opts:     Options{FallbackLocales: []Locale{fr}, EnglishFallback: true},
+			expected: time.Date(1988, time.October, 27, 0, 0, 0, 0, time.UTC),
+			names: []NameMatch{
+				{LayoutElem: "Mon", Value: "jeu.", Offset: 0, Language: "fr"},
+				{LayoutElem: "Jan", Value: "oct", Offset: 8, Language: "es"},
+			},
+		},
+		{
+			layout:   "2 January 2006 AD",
+			value:    "15 March 44 BC",
+			locale:   esEras,
+			opts:     Options{EnglishFallback: true},
+			expected: time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC),
+			names: []NameMatch{
+				{LayoutElem: "January", Value: "March", Offset: 3, Language: "en"},
+				{LayoutElem: "AD", Value: "BC", Offset: 12, Language: "en"},
+			},
+		},
+		{
+			// the time zones abbreviations are parsed by translating the value
+			layout:   "2 January 2006 MST",
+			value:    "27 octobre 1988 UTC",
+			locale:   es,
+			opts:     Options{FallbackLocales: []Locale{fr}},
+			expected: time.Date(1988, time.October, 27, 0, 0, 0, 0, time.UTC),
+			names:    []NameMatch{{LayoutElem: "January", Value: "octobre", Offset: 3, Language: "fr"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseWithResult(tt.layout, tt.value, tt.locale, tt.opts)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !result.Time.Equal(tt.expected) {
+				t.Errorf("expected: %v, got: %v", tt.expected, result.Time)
+			}
+
+			if !slices.Equal(result.Names, tt.names) {
+				t.Errorf("expected names: %v, got: %v", tt.names, result.Names)
+			}
+
+			// the fallback locales names are not matched by default
+			if _, err = ParseWithLocale(tt.layout, tt.value, tt.locale); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFallbackLocalesTranslate(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+
+	value, err := TranslateWithOptions("Monday 2 January 2006 3:04 pm", "Thursday 27 octubre 1988 11:53 pm", es, Options{EnglishFallback: true})
+	if err != nil || value != "Thursday 27 October 1988 11:53 pm" {
+		t.Errorf("expected: 'Thursday 27 October 1988 11:53 pm', got: '%s' ('%v')", value, err)
+	}
+
+	layout, err := CompileLayoutWithOptions("Mon 2 Jan 2006", es, Options{EnglishFallback: true})
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	// the layout locale mismatch is reported
+	_, err = layout.Parse("xyz 27 oct 1988")
+	var e *ErrParse
+	if !errors.As(err, &e) || e.LayoutElem != "Mon" || e.Offset != 0 {
+		t.Errorf("expected an ErrParse error at offset 0, got: '%v'", err)
+	}
+
+	got, err := layout.Parse("Thu 27 oct 1988")
+	expected := time.Date(1988, time.October, 27, 0, 0, 0, 0, time.UTC)
+	if err != nil || !got.Equal(expected) {
+		t.Errorf("expected: %v, got: %v ('%v')", expected, got, err)
+	}
+}
@@ -0,0 +1,175 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format returns a textual representation of the time value formatted according to the
+// layout, using the names of the provided locale. It is the inverse of [Translate]: the
+// short and long week days names, months names, and day periods are written in the foreign
+// language, while the remaining layout elements are formatted by the Go standard
+// [time.Time.Format] function. The lunes specific era layout elements (AD and Anno Domini)
+// are also supported, formatting the years before the common era using the BC numbering.
+//
+// The language argument must be a well-formed BCP 47 language tag, e.g ("en", "en-US") and
+// a known locale. If no data is found for the language, it returns ErrUnsupportedLocale.
+// If the given language does not support any [time.Layout] element specified on the layout
+// argument, it results in an ErrUnsupportedLayoutElem error.
+//
+// To execute several formats for the same locale, use [FormatWithLocale] as it performs better.
+func Format(t time.Time, layout string, lang string) (string, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatWithLocale(t, layout, locale)
+}
+
+// FormatWithLocale is like Format, but instead of receiving a BCP 47 language tag argument,
+// it receives a built [lunes.Locale], avoiding looking up existing data in each operation
+// and allowing extensibility.
+func FormatWithLocale(t time.Time, layout string, locale Locale) (string, error) {
+	b := make([]byte, 0, len(layout)+16)
+	b, err := AppendFormat(b, t, layout, locale)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// AppendFormat is like FormatWithLocale, but appends the textual representation to b and
+// returns the extended buffer.
+func AppendFormat(b []byte, t time.Time, layout string, locale Locale) ([]byte, error) {
+	// stdOffset marks the beginning of the layout chunk that has no localized elements,
+	// and is formatted as a whole by the time package.
+	var stdOffset, layoutOffset int
+
+	// years before the common era are formatted using the BC era numbering
+	eraLayout := isEraLayout(layout, locale)
+
+	for layoutOffset < len(layout) {
+		var layoutElem string
+		var lookupTab []string
+		var index int
+
+		switch c := int(layout[layoutOffset]); c {
+		case 'J': // January, Jan
+			if len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "Jan" {
+				if len(layout) >= layoutOffset+7 && layout[layoutOffset:layoutOffset+7] == "January" {
+					layoutElem = "January"
+					lookupTab = locale.LongMonthNames()
+				} else if !startsWithLowerCase(layout[layoutOffset+3:]) {
+					layoutElem = "Jan"
+					lookupTab = locale.ShortMonthNames()
+				}
+				index = int(t.Month()) - 1
+			}
+		case 'M': // Monday, Mon
+			if len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "Mon" {
+				if len(layout) >= layoutOffset+6 && layout[layoutOffset:layoutOffset+6] == "Monday" {
+					layoutElem = "Monday"
+					lookupTab = locale.LongDayNames()
+				} else if !startsWithLowerCase(layout[layoutOffset+3:]) {
+					layoutElem = "Mon"
+					lookupTab = locale.ShortDayNames()
+				}
+				index = int(t.Weekday())
+			}
+		case 'A': // Anno Domini, AD
+			if !eraLayout {
+				break
+			}
+
+			eras := locale.(EraLocale)
+			switch layoutElem = eraLayoutElemAt(layout, layoutOffset); layoutElem {
+			case "Anno Domini":
+				lookupTab = eras.LongEraNames()
+			case "AD":
+				lookupTab = eras.ShortEraNames()
+			}
+			if t.Year() > 0 {
+				index = 1
+			}
+		case '2': // 2006
+			if eraLayout && t.Year() <= 0 && len(layout) >= layoutOffset+4 && layout[layoutOffset:layoutOffset+4] == "2006" {
+				if stdOffset < layoutOffset {
+					b = t.AppendFormat(b, layout[stdOffset:layoutOffset])
+				}
+
+				b = appendYear(b, 1-t.Year())
+				layoutOffset += 4
+				stdOffset = layoutOffset
+				continue
+			}
+		case 'P', 'p': // PM, pm
+			if len(layout) >= layoutOffset+2 && (layout[layoutOffset+1] == 'M' || layout[layoutOffset+1] == 'm') {
+				if c == 'p' {
+					layoutElem = "pm"
+				} else {
+					layoutElem = "PM"
+				}
+				lookupTab = locale.DayPeriods()
+				if t.Hour() >= 12 {
+					index = 1
+				}
+			}
+		}
+
+		if layoutElem == "" {
+			layoutOffset++
+			continue
+		}
+
+		if index >= len(lookupTab) || lookupTab[index] == "" {
+			return b, newUnsupportedLayoutElemError(layoutElem, locale)
+		}
+
+		if stdOffset < layoutOffset {
+			b = t.AppendFormat(b, layout[stdOffset:layoutOffset])
+		}
+
+		name := lookupTab[index]
+		if layoutElem == "pm" {
+			name = strings.ToLower(name)
+		}
+
+		b = append(b, name...)
+		layoutOffset += len(layoutElem)
+		stdOffset = layoutOffset
+	}
+
+	if stdOffset < len(layout) {
+		b = t.AppendFormat(b, layout[stdOffset:])
+	}
+
+	return b, nil
+}
+
+// appendYear appends the positive year value, padded to the 4 digits of the 2006 layout element.
+func appendYear(b []byte, year int) []byte {
+	for d := 1000; d > 1 && year < d; d /= 10 {
+		b = append(b, '0')
+	}
+	return strconv.AppendInt(b, int64(year), 10)
+}
@@ -0,0 +1,257 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	value := time.Date(1988, time.October, 27, 23, 53, 29, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		layout string
+		lang   string
+		want   string
+	}{
+		{
+			name:   "LongNames",
+			layout: "Monday, 2 January 2006 15:04:05",
+			lang:   LocaleEsES,
+			want:   "jueves, 27 octubre 1988 23:53:29",
+		},
+		{
+			name:   "ShortNames",
+			layout: "Mon Jan _2 2006",
+			lang:   LocaleEsES,
+			want:   "jue oct 27 1988",
+		},
+		{
+			name:   "DayPeriod",
+			layout: "Jan 2 2006 3:04 PM",
+			lang:   LocaleEsES,
+			want:   "oct 27 1988 11:53 p.m.",
+		},
+		{
+			name:   "LowerDayPeriod",
+			layout: "3:04pm",
+			lang:   LocaleEnUS,
+			want:   "11:53pm",
+		},
+		{
+			name:   "NonLayoutWords",
+			layout: "Month: January, Janx",
+			lang:   LocaleDe,
+			want:   "Month: Oktober, Janx",
+		},
+		{
+			name:   "English",
+			layout: time.RFC1123,
+			lang:   LocaleEn,
+			want:   value.Format(time.RFC1123),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(value, tt.layout, tt.lang)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected value %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAppendFormat(t *testing.T) {
+	locale, err := NewDefaultLocale(LocaleFr)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	value := time.Date(2024, time.July, 1, 10, 0, 0, 0, time.UTC)
+	b, err := AppendFormat([]byte("date: "), value, "Monday 2 January 2006", locale)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	if string(b) != "date: lundi 1 juillet 2024" {
+		t.Errorf("expected value \"date: lundi 1 juillet 2024\", got: %q", string(b))
+	}
+}
+
+func TestFormatEras(t *testing.T) {
+	locale := newTestLocale(LocaleEs, esErasTestFields)
+
+	tests := []struct {
+		name   string
+		value  time.Time
+		layout string
+		want   string
+	}{
+		{
+			name:   "AD",
+			value:  time.Date(1988, time.October, 27, 0, 0, 0, 0, time.UTC),
+			layout: "2 Jan 2006 AD",
+			want:   "27 oct 1988 d. C.",
+		},
+		{
+			name:   "BC",
+			value:  time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC),
+			layout: "2 January 2006 Anno Domini",
+			want:   "15 marzo 0044 antes de Cristo",
+		},
+		{
+			name:   "YearZero",
+			value:  time.Date(0, time.March, 15, 0, 0, 0, 0, time.UTC),
+			layout: "2006 AD",
+			want:   "0001 a. C.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatWithLocale(tt.value, tt.layout, locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected value %q, got: %q", tt.want, got)
+			}
+
+			parsed, err := ParseWithLocale(tt.layout, got, locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if parsed.Year() != tt.value.Year() {
+				t.Errorf("expected year %d, got: %d", tt.value.Year(), parsed.Year())
+			}
+		})
+	}
+}
+
+func TestFormatUnsupportedLayoutElements(t *testing.T) {
+	locale := genericLocale{
+		lang: LocaleEn,
+		table: [localeTableSize][]string{
+			{}, {}, {}, {}, {},
+		},
+	}
+
+	for _, elem := range []string{"Mon", "Monday", "Jan", "January", "PM", "pm"} {
+		t.Run(elem, func(t *testing.T) {
+			expectedErr := newUnsupportedLayoutElemError(elem, &locale)
+			_, err := FormatWithLocale(time.Now(), "2006 "+elem, &locale)
+			if !errors.Is(err, expectedErr) {
+				t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+			}
+		})
+	}
+
+	// the era elements are supported by the locales with any era names, and are literals otherwise
+	longEras := newTestLocale(LocaleEn, map[int][]string{longEraNamesField: {"Before Christ", "Anno Domini"}, shortEraNamesField: nil})
+	shortEras := newTestLocale(LocaleEn, map[int][]string{longEraNamesField: nil, shortEraNamesField: {"BC", "AD"}})
+	for elem, locale := range map[string]Locale{"AD": longEras, "Anno Domini": shortEras} {
+		t.Run(elem, func(t *testing.T) {
+			expectedErr := newUnsupportedLayoutElemError(elem, locale)
+			_, err := FormatWithLocale(time.Now(), "2006 "+elem, locale)
+			if !errors.Is(err, expectedErr) {
+				t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFormatWithUnsupportedLocale(t *testing.T) {
+	lang := "ann"
+	_, err := Format(time.Now(), time.RFC1123, lang)
+	var e *ErrUnsupportedLocale
+	if !errors.As(err, &e) {
+		t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{lang}, err)
+	}
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	layouts := []string{
+		"Monday January 02 2006 03:04:05PM",
+		"Mon Jan 02 2006 03:04:05PM",
+	}
+
+	for lang := range tableLoaders {
+		locale, err := NewDefaultLocale(lang)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		// round trips are only deterministic when all names are distinct
+		if hasDuplicatedNames(locale) {
+			continue
+		}
+
+		for _, layout := range layouts {
+			for month := time.January; month <= time.December; month++ {
+				value := time.Date(2024, month, int(month)*2, int(month)*2-1, 4, 5, 0, time.UTC)
+
+				formatted, err := FormatWithLocale(value, layout, locale)
+				if err != nil {
+					var ule *ErrUnsupportedLayoutElem
+					if !errors.As(err, &ule) {
+						t.Errorf("'%s' expected ErrUnsupportedLayoutElem, got: '%v'", lang, err)
+					}
+					break
+				}
+
+				parsed, err := ParseWithLocale(layout, formatted, locale)
+				if err != nil {
+					t.Errorf("'%s' error parsing formatted value %q: %v", lang, formatted, err)
+					break
+				}
+
+				if !parsed.Equal(value) || parsed.Weekday() != value.Weekday() {
+					t.Errorf("'%s' expected %v parsing %q, got: %v", lang, value, formatted, parsed)
+				}
+			}
+		}
+	}
+}
+
+func hasDuplicatedNames(locale Locale) bool {
+	for _, tab := range [][]string{
+		locale.ShortDayNames(),
+		locale.LongDayNames(),
+		locale.ShortMonthNames(),
+		locale.LongMonthNames(),
+		locale.DayPeriods(),
+	} {
+		for i, v := range tab {
+			if v == "" || slices.Index(tab, v) != i {
+				return true
+			}
+		}
+	}
+	return false
+}
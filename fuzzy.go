@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// minFuzzyWordLen is the minimum number of runes of the value words matched fuzzily, as
+// shorter words are too close to many names.
+const minFuzzyWordLen = 4
+
+// minPrefixWordLen is the minimum number of runes of the value words matched as names
+// prefixes by the AnyWidthNames option.
+const minPrefixWordLen = 3
+
+// isWordLayoutElem reports whether the layout element names are words matched by their
+// prefixes, or fuzzily, which only applies to the days and months names.
+func isWordLayoutElem(elem string) bool {
+	switch elem {
+	case "January", "Jan", "Monday", "Mon":
+		return true
+	}
+	return false
+}
+
+// wordMatch is the way a value word matched a name.
+type wordMatch uint8
+
+const (
+	exactWord wordMatch = iota
+	prefixWord
+	fuzzyWord
+)
+
+// lookupWord is like lookupNames, but if no name matches the value, or the matched name ends
+// inside a word, it finds the day or month name the value word abbreviates (AnyWidthNames),
+// or misspells (FuzzyNames), as the options enable, returning how the word matched it.
+func lookupWord(offset int, val string, elem string, trie *nameTrie, opts *Options, lookupTabs ...[]string) (newOffset, skippedSpaces int, index int, matched string, ambiguous bool, match wordMatch) {
+	newOffset, skippedSpaces, index, matched, ambiguous = lookupNames(offset, val, trie, opts, lookupTabs...)
+	if (!opts.AnyWidthNames && !opts.FuzzyNames) || !isWordLayoutElem(elem) {
+		return newOffset, skippedSpaces, index, matched, ambiguous, exactWord
+	}
+
+	if index >= 0 && wordLen(val[newOffset:]) <= len(matched) {
+		return newOffset, skippedSpaces, index, matched, ambiguous, exactWord
+	}
+
+	if opts.AnyWidthNames {
+		o, s, i, m, a := lookupPrefix(offset, val, opts, lookupTabs...)
+		if i >= 0 {
+			return o, s, i, m, a, prefixWord
+		}
+	}
+
+	if opts.FuzzyNames {
+		o, s, i, m, a := lookupFuzzy(offset, val, opts, lookupTabs...)
+		if i >= 0 {
+			return o, s, i, m, a, fuzzyWord
+		}
+	}
+
+	return newOffset, skippedSpaces, index, matched, ambiguous, exactWord
+}
+
+// maxFuzzyDistance returns the maximum edit distance of the words with n runes matched
+// fuzzily, or -1 if they are too short.
+func maxFuzzyDistance(n int) int {
+	switch {
+	case n < minFuzzyWordLen:
+		return -1
+	case n < 8:
+		return 1
+	}
+	return 2
+}
+
+// lookupFuzzy finds the lookup tables name closest to the value word starting at the offset,
+// ignoring case, where a word is a run of letters. The names prefixes are compared as well,
+// so abbreviations are matched too (e.g. "mierc" matches "miércoles"). It returns the name
+// index, or -1 if no name is close enough, and sets ambiguous if names of other indexes are
+// as close.
+func lookupFuzzy(offset int, val string, opts *Options, lookupTabs ...[]string) (newOffset, skippedSpaces int, index int, matched string, ambiguous bool) {
+	index = -1
+	newOffset, skippedSpaces = skipNamesSpaces(val, offset, opts.Spaces)
+	matched = val[newOffset : newOffset+wordLen(val[newOffset:])]
+	word := foldRunes(matched)
+	maxDistance := maxFuzzyDistance(len(word))
+	if maxDistance < 0 {
+		return newOffset, skippedSpaces, index, matched, false
+	}
+
+	distance := maxDistance + 1
+	for _, lookupTab := range lookupTabs {
+		for i, v := range lookupTab {
+			if v == "" {
+				continue
+			}
+
+			runes := foldRunes(v)
+			d := editDistance(word, runes)
+			if len(word) < len(runes) {
+				d = min(d, editDistance(word, runes[:len(word)]))
+			}
+
+			switch {
+			case d < distance:
+				index, distance, ambiguous = i, d, false
+			case d == distance && index >= 0 && i != index:
+				ambiguous = true
+			}
+		}
+	}
+
+	return newOffset, skippedSpaces, index, matched, ambiguous
+}
+
+// lookupPrefix finds the lookup tables names starting with the value word at the offset,
+// ignoring case unless the options are case-sensitive, where a word is a run of letters. It
+// returns the name index, or -1 if no name starts with it, and sets ambiguous if names of
+// other indexes do too.
+func lookupPrefix(offset int, val string, opts *Options, lookupTabs ...[]string) (newOffset, skippedSpaces int, index int, matched string, ambiguous bool) {
+	index = -1
+	newOffset, skippedSpaces = skipNamesSpaces(val, offset, opts.Spaces)
+	matched = val[newOffset : newOffset+wordLen(val[newOffset:])]
+	if utf8.RuneCountInString(matched) < minPrefixWordLen {
+		return newOffset, skippedSpaces, index, matched, false
+	}
+
+	for _, lookupTab := range lookupTabs {
+		for i, v := range lookupTab {
+			if !hasNamePrefix(v, matched, opts.CaseSensitive) {
+				continue
+			}
+
+			if index >= 0 && i != index {
+				ambiguous = true
+			}
+			index = i
+		}
+	}
+
+	return newOffset, skippedSpaces, index, matched, ambiguous
+}
+
+// wordLen returns the length of the word starting the value, which is a run of letters
+// and their marks.
+func wordLen(value string) int {
+	n := 0
+	for n < len(value) {
+		r, size := utf8.DecodeRuneInString(value[n:])
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// hasNamePrefix reports whether the name starts with the prefix, ignoring case unless
+// caseSensitive is set.
+func hasNamePrefix(name string, prefix string, caseSensitive bool) bool {
+	for _, r := range prefix {
+		nr, size := utf8.DecodeRuneInString(name)
+		if size == 0 || (nr != r && (caseSensitive || foldRune(nr) != foldRune(r))) {
+			return false
+		}
+		name = name[size:]
+	}
+	return true
+}
@@ -0,0 +1,230 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFuzzyNames(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	en, _ := NewDefaultLocale(LocaleEn)
+	opts := Options{FuzzyNames: true}
+
+	tests := []struct {
+		layout   string
+		value    string
+		locale   Locale
+		expected time.Time
+		names    []NameMatch
+	}{
+		{
+			layout:   "2 January 2006",
+			value:    "27 Setiembre 1988",
+			locale:   es,
+			expected: time.Date(1988, time.September, 27, 0, 0, 0, 0, time.UTC),
+			names:    []NameMatch{{LayoutElem: "January", Value: "Setiembre", Offset: 3, Language: "es", Fuzzy: true}},
+		},
+		{
+			layout:   "January 2, 2006",
+			value:    "Febraury 29, 2024",
+			locale:   en,
+			expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			names:    []NameMatch{{LayoutElem: "January", Value: "Febraury", Offset: 0, Language: "en", Fuzzy: true}},
+		},
+		{
+			layout:   "Monday 2 January 2006",
+			value:    "mierc 26 octubre 1988",
+			locale:   es,
+			expected: time.Date(1988, time.October, 26, 0, 0, 0, 0, time.UTC),
+			names: []NameMatch{
+				{LayoutElem: "Monday", Value: "mierc", Offset: 0, Language: "es", Fuzzy: true},
+				{LayoutElem: "January", Value: "octubre", Offset: 9, Language: "es"},
+			},
+		},
+		{
+			layout:   "Mon 2 Jan 2006 3:04 PM",
+			value:    "mié 26 ocbt 1988 3:04 p.m.",
+			locale:   es,
+			expected: time.Date(1988, time.October, 26, 15, 4, 0, 0, time.UTC),
+			names: []NameMatch{
+				{LayoutElem: "Mon", Value: "mié", Offset: 0, Language: "es"},
+				{LayoutElem: "Jan", Value: "ocbt", Offset: 8, Language: "es", Fuzzy: true},
+				{LayoutElem: "PM", Value: "p.m.", Offset: 23, Language: "es"},
+			},
+		},
+		{
+			// the time zones abbreviations are parsed by translating the value
+			layout:   "2 January 2006 MST",
+			value:    "27 otcubre 1988 UTC",
+			locale:   es,
+			expected: time.Date(1988, time.October, 27, 0, 0, 0, 0, time.UTC),
+			names:    []NameMatch{{LayoutElem: "January", Value: "otcubre", Offset: 3, Language: "es", Fuzzy: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseWithResult(tt.layout, tt.value, tt.locale, opts)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !result.Time.Equal(tt.expected) {
+				t.Errorf("expected: %v, got: %v", tt.expected, result.Time)
+			}
+
+			if !slices.Equal(result.Names, tt.names) {
+				t.Errorf("expected names: %v, got: %v", tt.names, result.Names)
+			}
+
+			if !result.Fuzzy() {
+				t.Error("expected a fuzzy result")
+			}
+
+			// the values misspelling names are not matched by default
+			var mismatch *ErrLayoutMismatch
+			if _, err = ParseWithLocale(tt.layout, tt.value, tt.locale); !errors.As(err, &mismatch) {
+				t.Errorf("expected an ErrLayoutMismatch error, got: '%v'", err)
+			}
+		})
+	}
+}
+
+func TestFuzzyNamesMismatch(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	opts := Options{FuzzyNames: true}
+
+	// as close to junio as julio
+	_, err := ParseWithOptions("2 January 2006", "27 jupio 1988", es, opts)
+	var ambiguous *ErrAmbiguousValue
+	if !errors.As(err, &ambiguous) {
+		t.Errorf("expected an ErrAmbiguousValue error, got: '%v'", err)
+	}
+
+	// too short or too far from any name
+	var mismatch *ErrLayoutMismatch
+	for _, value := range []string{"27 ocb 1988", "27 ocutbure 1988", "27 xyzw 1988"} {
+		if _, err = ParseWithOptions("2 Jan 2006", value, es, opts); !errors.As(err, &mismatch) {
+			t.Errorf("%s: expected an ErrLayoutMismatch error, got: '%v'", value, err)
+		}
+	}
+
+	// the day periods and eras are not matched fuzzily
+	if _, err = ParseWithOptions("3:04 PM", "3:04 p.mm.", es, opts); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestFuzzyNamesTranslate(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+
+	value, err := TranslateWithOptions("Monday 2 January 2006", "mierc 26 Setiembre 1988", es, Options{FuzzyNames: true})
+	if err != nil || value != "Wednesday 26 September 1988" {
+		t.Errorf("expected: 'Wednesday 26 September 1988', got: '%s' ('%v')", value, err)
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	arab := newTestLocale(LocaleArEG, map[int][]string{numberingSystemField: {"arab"}})
+
+	result, err := ParseInLocationWithResult("Monday, 2 January 2006", "jueves, 27 octubre 1988", defaultLocation, es, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	expected := time.Date(1988, time.October, 27, 0, 0, 0, 0, defaultLocation)
+	names := []NameMatch{{LayoutElem: "Monday", Value: "jueves", Offset: 0, Language: "es"}, {LayoutElem: "January", Value: "octubre", Offset: 11, Language: "es"}}
+	if !result.Time.Equal(expected) || !slices.Equal(result.Names, names) || result.Fuzzy() {
+		t.Errorf("expected: %v %v, got: %v %v", expected, names, result.Time, result.Names)
+	}
+
+	// the offsets refer to the value with its native digits
+	layout, _ := CompileLayout("02 January 2006", arab)
+	result, err = layout.ParseWithResult("٢٧ أكتوبر ١٩٨٨")
+	names = []NameMatch{{LayoutElem: "January", Value: "أكتوبر", Offset: 5, Language: "ar-EG"}}
+	if err != nil || !slices.Equal(result.Names, names) {
+		t.Errorf("expected: %v, got: %v ('%v')", names, result.Names, err)
+	}
+}
+
+func TestAnyWidthNames(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+	opts := Options{AnyWidthNames: true}
+
+	tests := []struct {
+		layout   string
+		value    string
+		locale   Locale
+		expected time.Time
+	}{
+		{layout: "2 Jan 2006", value: "1 mayo 2024", locale: es, expected: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{layout: "2 January 2006", value: "1 sept 2024", locale: es, expected: time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{layout: "2 January 2006", value: "1 septiem 2024", locale: es, expected: time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{layout: "Mon 2 Jan 2006", value: "miércoles 4 Septiem 2024", locale: es, expected: time.Date(2024, time.September, 4, 0, 0, 0, 0, time.UTC)},
+		{layout: "Monday, 2 January 2006", value: "miérc, 4 sept 2024", locale: es, expected: time.Date(2024, time.September, 4, 0, 0, 0, 0, time.UTC)},
+		{layout: "2 Jan 2006 AD", value: "15 mar 44 antes de Cristo", locale: esEras, expected: time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{layout: "2 Jan 2006 MST", value: "1 agost 2024 UTC", locale: es, expected: time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout+" "+tt.value, func(t *testing.T) {
+			got, err := ParseWithOptions(tt.layout, tt.value, tt.locale, opts)
+			if err != nil || !got.Equal(tt.expected) {
+				t.Errorf("expected: %v, got: %v ('%v')", tt.expected, got, err)
+			}
+
+			// the names of other widths are not matched by default
+			if _, err = ParseWithLocale(tt.layout, tt.value, tt.locale); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestAnyWidthNamesMismatch(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	fr, _ := NewDefaultLocale(LocaleFr)
+	opts := Options{AnyWidthNames: true}
+
+	// juin and juillet share the prefix
+	_, err := ParseWithOptions("2 Jan 2006", "1 jui 2024", fr, opts)
+	var ambiguous *ErrAmbiguousValue
+	if !errors.As(err, &ambiguous) {
+		t.Errorf("expected an ErrAmbiguousValue error, got: '%v'", err)
+	}
+
+	// the layout still sets the field, and the prefixes are at least 3 letters long
+	var mismatch *ErrLayoutMismatch
+	for _, value := range []string{"1 lunes 2024", "1 ag 2024"} {
+		if _, err = ParseWithOptions("2 Jan 2006", value, es, opts); !errors.As(err, &mismatch) {
+			t.Errorf("%s: expected an ErrLayoutMismatch error, got: '%v'", value, err)
+		}
+	}
+
+	// the prefixes are matched before the fuzzy names
+	result, err := ParseWithResult("2 January 2006", "1 octub 2024", es, Options{AnyWidthNames: true, FuzzyNames: true})
+	if err != nil || result.Time.Month() != time.October || result.Fuzzy() {
+		t.Errorf("expected an October exact result, got: %v %v ('%v')", result.Time, result.Names, err)
+	}
+}
@@ -33,10 +33,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	"golang.org/x/text/language"
 )
@@ -116,11 +118,59 @@ var shortDayNamesStdMap = map[string]string{
 	"sat": "Sat",
 }
 
+var erasStd = []string{
+	"BC",
+	"AD",
+}
+
+var erasStdMap = map[string]string{
+	"0": "BC",
+	"1": "AD",
+}
+
 var dayPeriodsStdMap = map[string]string{
 	"am": "AM",
 	"pm": "PM",
 }
 
+// flexibleDayPeriodsStd holds the CLDR day periods types, in the generated tables order.
+var flexibleDayPeriodsStd = []string{
+	"midnight",
+	"am",
+	"noon",
+	"pm",
+	"morning1",
+	"morning2",
+	"afternoon1",
+	"afternoon2",
+	"evening1",
+	"evening2",
+	"night1",
+	"night2",
+}
+
+// timeZoneFormatsStd holds the CLDR time zones formats, in the generated tables order.
+var timeZoneFormatsStd = []string{
+	"gmtFormat",
+	"hourFormat",
+	"gmtZeroFormat",
+	"regionFormat",
+	"regionFormatDaylight",
+	"regionFormatStandard",
+}
+
+// relativeTimeFieldsStd holds the CLDR date fields supporting relative times.
+var relativeTimeFieldsStd = []string{
+	"year", "quarter", "month", "week", "day", "hour", "minute", "second",
+	"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+}
+
+// formatLengthsStd holds the CLDR date and time formats lengths, in the generated tables order.
+var formatLengthsStd = []string{"full", "long", "medium", "short"}
+
+// zoneNameKinds holds the CLDR zones names types, in the generated tables order.
+var zoneNameKinds = []string{"generic", "standard", "daylight"}
+
 var localesData = map[string]*cldrLocaleData{}
 
 func main() {
@@ -128,7 +178,7 @@ func main() {
 	cldrZipFilePath := flag.String("file", "", "CLDR core.zip path")
 	flag.Parse()
 
-	data, err := readCLDRCoreFile(*cldrZipFilePath, *cldrVersion)
+	data, supplemental, err := readCLDRCoreFile(*cldrZipFilePath, *cldrVersion)
 	if err != nil {
 		log.Fatalf("failed to read CLDR zip: %v", err)
 	}
@@ -159,6 +209,20 @@ func main() {
 			}
 		}
 
+		fillTimeZoneData(localeLDML.LDML, &localeCalendar)
+		fillRelativeTimeData(localeLDML.LDML, &localeCalendar)
+
+		if numberingSystem := lookupDefaultNumberingSystem(localeLDML.LDML); numberingSystem != "" {
+			localeCalendar.numberingSystem = numberingSystem
+		}
+
+		if localeCalendar.hasFlexibleDayPeriods() {
+			localeCalendar.dayPeriodRules = lookupLanguageRules(supplemental.dayPeriodRules, parsedTag)
+		}
+
+		localeCalendar.pluralRules = lookupLanguageRules(supplemental.pluralRules, parsedTag)
+		localeCalendar.hourCycle = lookupHourCycle(supplemental.hourCycles, parsedTag)
+
 		if !localeCalendar.isEmpty() {
 			localesData[tag] = &localeCalendar
 			nonEmptyLanguages = append(nonEmptyLanguages, tag)
@@ -179,7 +243,7 @@ func main() {
 		}
 	}
 
-	err = writeTableGoFile(cldrVersion, tablesTmplDataItems)
+	err = writeTableGoFile(cldrVersion, tablesTmplDataItems, supplemental)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -189,20 +253,33 @@ func fillLocaleData(tag string, gregorianCalendar *Calendar, locale *cldrLocaleD
 	var err error
 	if gregorianCalendar.Months != nil && gregorianCalendar.Months.MonthContext != nil {
 		for _, monthContext := range gregorianCalendar.Months.MonthContext {
-			if monthContext.Type != "format" {
+			var shortMonthNames, longMonthNames *map[string]string
+			switch monthContext.Type {
+			case "format":
+				shortMonthNames, longMonthNames = &locale.shortMonthNames, &locale.longMonthNames
+			case "stand-alone":
+				shortMonthNames, longMonthNames = &locale.standAloneShortMonthNames, &locale.standAloneLongMonthNames
+			default:
 				continue
 			}
 
 			for _, monthWidth := range monthContext.MonthWidth {
 				if monthWidth.Type == "abbreviated" {
-					locale.shortMonthNames, err = lookupMonthValue(locale.shortMonthNames, shortMonthNamesStd, monthWidth.Month)
+					*shortMonthNames, err = lookupMonthValue(*shortMonthNames, shortMonthNamesStd, monthWidth.Month)
 					if err != nil {
-						return fmt.Errorf("failed to read %s short month names %w", tag, err)
+						return fmt.Errorf("failed to read %s %s short month names %w", tag, monthContext.Type, err)
 					}
 				} else if monthWidth.Type == "wide" {
-					locale.longMonthNames, err = lookupMonthValue(locale.longMonthNames, longMonthNamesStd, monthWidth.Month)
+					*longMonthNames, err = lookupMonthValue(*longMonthNames, longMonthNamesStd, monthWidth.Month)
 					if err != nil {
-						return fmt.Errorf("failed to read %s long month names %w", tag, err)
+						return fmt.Errorf("failed to read %s %s long month names %w", tag, monthContext.Type, err)
+					}
+				} else if monthWidth.Type == "narrow" {
+					// narrow names rarely differ between contexts, and most of the locales
+					// define them on the stand-alone context only, so both are merged.
+					locale.narrowMonthNames, err = lookupMonthValue(locale.narrowMonthNames, shortMonthNamesStd, monthWidth.Month)
+					if err != nil {
+						return fmt.Errorf("failed to read %s %s narrow month names %w", tag, monthContext.Type, err)
 					}
 				}
 			}
@@ -211,15 +288,26 @@ func fillLocaleData(tag string, gregorianCalendar *Calendar, locale *cldrLocaleD
 
 	if gregorianCalendar.Days != nil && gregorianCalendar.Days.DayContext != nil {
 		for _, dayContext := range gregorianCalendar.Days.DayContext {
-			if dayContext.Type != "format" {
+			var shortDayNames, longDayNames *map[string]string
+			switch dayContext.Type {
+			case "format":
+				shortDayNames, longDayNames = &locale.shortDayNames, &locale.longDayNames
+			case "stand-alone":
+				shortDayNames, longDayNames = &locale.standAloneShortDayNames, &locale.standAloneLongDayNames
+			default:
 				continue
 			}
 
 			for _, dayWidth := range dayContext.DayWidth {
 				if dayWidth.Type == "abbreviated" {
-					locale.shortDayNames = lookupDayValue(locale.shortDayNames, shortDayNamesStdMap, dayWidth.Day)
+					*shortDayNames = lookupDayValue(*shortDayNames, shortDayNamesStdMap, dayWidth.Day)
 				} else if dayWidth.Type == "wide" {
-					locale.longDayNames = lookupDayValue(locale.longDayNames, longDayNamesStdMap, dayWidth.Day)
+					*longDayNames = lookupDayValue(*longDayNames, longDayNamesStdMap, dayWidth.Day)
+				} else if dayWidth.Type == "short" {
+					// like the narrow names, both contexts are merged.
+					locale.minDayNames = lookupDayValue(locale.minDayNames, shortDayNamesStdMap, dayWidth.Day)
+				} else if dayWidth.Type == "narrow" {
+					locale.narrowDayNames = lookupDayValue(locale.narrowDayNames, shortDayNamesStdMap, dayWidth.Day)
 				}
 			}
 		}
@@ -231,6 +319,17 @@ func fillLocaleData(tag string, gregorianCalendar *Calendar, locale *cldrLocaleD
 				continue
 			}
 
+			for _, periodWidth := range periodContext.DayPeriodWidth {
+				switch periodWidth.Type {
+				case "wide":
+					locale.longFlexibleDayPeriods = lookupDayPeriodValue(locale.longFlexibleDayPeriods, periodWidth.DayPeriod)
+				case "abbreviated":
+					locale.shortFlexibleDayPeriods = lookupDayPeriodValue(locale.shortFlexibleDayPeriods, periodWidth.DayPeriod)
+				case "narrow":
+					locale.narrowFlexibleDayPeriods = lookupDayPeriodValue(locale.narrowFlexibleDayPeriods, periodWidth.DayPeriod)
+				}
+			}
+
 			periods := map[string]string{}
 			for _, periodWidth := range periodContext.DayPeriodWidth {
 				if periodWidth.Type != "abbreviated" && periodWidth.Type != "narrow" {
@@ -256,9 +355,75 @@ func fillLocaleData(tag string, gregorianCalendar *Calendar, locale *cldrLocaleD
 		}
 	}
 
+	if gregorianCalendar.Eras != nil {
+		locale.longEraNames = lookupEraValue(locale.longEraNames, gregorianCalendar.Eras.EraNames)
+		locale.shortEraNames = lookupEraValue(locale.shortEraNames, gregorianCalendar.Eras.EraAbbr)
+		locale.narrowEraNames = lookupEraValue(locale.narrowEraNames, gregorianCalendar.Eras.EraNarrow)
+	}
+
+	if gregorianCalendar.DateFormats != nil {
+		locale.dateFormats = lookupFormatValue(locale.dateFormats, gregorianCalendar.DateFormats.FormatLength)
+	}
+
+	if gregorianCalendar.TimeFormats != nil {
+		locale.timeFormats = lookupFormatValue(locale.timeFormats, gregorianCalendar.TimeFormats.FormatLength)
+	}
+
+	if gregorianCalendar.DateTimeFormats != nil {
+		locale.dateTimeFormats = lookupFormatValue(locale.dateTimeFormats, gregorianCalendar.DateTimeFormats.FormatLength)
+	}
+
 	return nil
 }
 
+func lookupDayPeriodValue(curr map[string]string, lookupTable []*Common) map[string]string {
+	if curr == nil && len(lookupTable) == 0 {
+		return nil
+	}
+
+	val := make(map[string]string, len(flexibleDayPeriodsStd))
+	if curr != nil {
+		maps.Copy(val, curr)
+	}
+
+	for _, period := range lookupTable {
+		if period.Alt == "variant" || !slices.Contains(flexibleDayPeriodsStd, period.Type) {
+			continue
+		}
+
+		val[period.Type] = strings.ReplaceAll(period.CharData, "\u202F", "")
+	}
+
+	return val
+}
+
+func lookupEraValue(curr map[string]string, eraWidth *EraWidth) map[string]string {
+	if curr == nil && (eraWidth == nil || len(eraWidth.Era) == 0) {
+		return nil
+	}
+
+	val := make(map[string]string, 2)
+	if curr != nil {
+		maps.Copy(val, curr)
+	}
+
+	if eraWidth == nil {
+		return val
+	}
+
+	for _, era := range eraWidth.Era {
+		e, ok := erasStdMap[era.Type]
+		// preference for non-variant eras (e.g. BC instead of BCE)
+		if !ok || era.Alt == "variant" {
+			continue
+		}
+
+		val[e] = era.CharData
+	}
+
+	return val
+}
+
 func lookupMonthValue(curr map[string]string, stdTab []string, lookupTable []*MonthWidth) (map[string]string, error) {
 	if curr == nil && len(lookupTable) == 0 {
 		return nil, nil
@@ -303,6 +468,188 @@ func lookupDayValue(curr map[string]string, stdTab map[string]string, lookupTabl
 	return val
 }
 
+func fillTimeZoneData(lang *LDML, locale *cldrLocaleData) {
+	if lang == nil || lang.Dates == nil || lang.Dates.TimeZoneNames == nil {
+		return
+	}
+
+	timeZoneNames := lang.Dates.TimeZoneNames
+	formats := map[string][]*Common{
+		"hourFormat":    timeZoneNames.HourFormat,
+		"gmtFormat":     timeZoneNames.GmtFormat,
+		"gmtZeroFormat": timeZoneNames.GmtZeroFormat,
+	}
+
+	for _, regionFormat := range timeZoneNames.RegionFormat {
+		switch regionFormat.Type {
+		case "":
+			formats["regionFormat"] = append(formats["regionFormat"], regionFormat)
+		case "daylight":
+			formats["regionFormatDaylight"] = append(formats["regionFormatDaylight"], regionFormat)
+		case "standard":
+			formats["regionFormatStandard"] = append(formats["regionFormatStandard"], regionFormat)
+		}
+	}
+
+	for key, values := range formats {
+		for _, value := range values {
+			if value.Alt == "" && isZoneValue(value.CharData) {
+				if locale.timeZoneFormats == nil {
+					locale.timeZoneFormats = make(map[string]string, len(timeZoneFormatsStd))
+				}
+				locale.timeZoneFormats[key] = value.CharData
+				break
+			}
+		}
+	}
+
+	for _, zone := range append(timeZoneNames.Zone, timeZoneNames.Metazone...) {
+		locale.longZoneNames = lookupZoneNames(locale.longZoneNames, zone.Type, zone.Long)
+		locale.shortZoneNames = lookupZoneNames(locale.shortZoneNames, zone.Type, zone.Short)
+
+		for _, city := range zone.ExemplarCity {
+			if city.Alt == "" && isZoneValue(city.CharData) {
+				if locale.exemplarCities == nil {
+					locale.exemplarCities = make(map[string]string)
+				}
+				locale.exemplarCities[zone.Type] = city.CharData
+				break
+			}
+		}
+	}
+}
+
+func fillRelativeTimeData(lang *LDML, locale *cldrLocaleData) {
+	if lang == nil || lang.Dates == nil || lang.Dates.Fields == nil {
+		return
+	}
+
+	for _, field := range lang.Dates.Fields.Field {
+		unit, width, _ := strings.Cut(field.Type, "-")
+		if !slices.Contains(relativeTimeFieldsStd, unit) {
+			continue
+		}
+
+		var relativeTimes *map[string]string
+		switch width {
+		case "":
+			relativeTimes = &locale.longRelativeTimes
+		case "short":
+			relativeTimes = &locale.shortRelativeTimes
+		case "narrow":
+			relativeTimes = &locale.narrowRelativeTimes
+		default:
+			continue
+		}
+
+		set := func(key, value string) {
+			if *relativeTimes == nil {
+				*relativeTimes = make(map[string]string)
+			}
+			(*relativeTimes)[key] = value
+		}
+
+		for _, relative := range field.Relative {
+			if relative.Alt == "" && isZoneValue(relative.CharData) {
+				set(unit+":"+relative.Type, relative.CharData)
+			}
+		}
+
+		for _, relativeTime := range field.RelativeTime {
+			if relativeTime.Type != "future" && relativeTime.Type != "past" {
+				continue
+			}
+
+			for _, pattern := range relativeTime.RelativeTimePattern {
+				if pattern.Alt == "" && isZoneValue(pattern.CharData) {
+					set(unit+":"+relativeTime.Type+":"+pattern.Count, pattern.CharData)
+				}
+			}
+		}
+	}
+}
+
+func lookupZoneNames(curr map[zoneNameKey]string, zone string, names *ZoneNames) map[zoneNameKey]string {
+	if names == nil {
+		return curr
+	}
+
+	for kind, values := range map[string][]*Common{
+		"generic":  names.Generic,
+		"standard": names.Standard,
+		"daylight": names.Daylight,
+	} {
+		for _, value := range values {
+			if value.Alt != "" || !isZoneValue(value.CharData) {
+				continue
+			}
+
+			if curr == nil {
+				curr = make(map[zoneNameKey]string)
+			}
+			curr[zoneNameKey{zone, kind}] = value.CharData
+			break
+		}
+	}
+
+	return curr
+}
+
+// isZoneValue reports whether the time zone value is not a CLDR inheritance or
+// no-value marker.
+func lookupFormatValue(curr map[string]string, lengths []*FormatLength) map[string]string {
+	if curr == nil && len(lengths) == 0 {
+		return nil
+	}
+
+	val := make(map[string]string, len(formatLengthsStd))
+	if curr != nil {
+		maps.Copy(val, curr)
+	}
+
+	for _, length := range lengths {
+		if !slices.Contains(formatLengthsStd, length.Type) {
+			continue
+		}
+
+		for _, format := range length.Format {
+			// the typed formats (e.g. "atTime") are meant for formatting messages
+			if format.Type != "" {
+				continue
+			}
+
+			for _, pattern := range format.Pattern {
+				if pattern.Alt != "" {
+					continue
+				}
+
+				// values are usually written using ASCII spaces
+				val[length.Type] = strings.NewReplacer("\u202F", " ", "\u00A0", " ").Replace(pattern.CharData)
+			}
+		}
+	}
+
+	return val
+}
+
+func isZoneValue(value string) bool {
+	return value != "" && value != "↑↑↑" && value != "∅∅∅"
+}
+
+func lookupDefaultNumberingSystem(lang *LDML) string {
+	if lang == nil || lang.Numbers == nil {
+		return ""
+	}
+
+	for _, numberingSystem := range lang.Numbers.DefaultNumberingSystem {
+		if numberingSystem.Alt == "" {
+			return numberingSystem.CharData
+		}
+	}
+
+	return ""
+}
+
 func findGregorianCalendar(lang *LDML) *Calendar {
 	if lang == nil || lang.Dates == nil || lang.Dates.Calendars == nil || lang.Dates.Calendars.Calendar == nil {
 		return nil
@@ -317,22 +664,23 @@ func findGregorianCalendar(lang *LDML) *Calendar {
 	return nil
 }
 
-func readCLDRCoreFile(path string, version int) (map[string]*cldrLocaleModel, error) {
+func readCLDRCoreFile(path string, version int) (map[string]*cldrLocaleModel, *cldrSupplementalData, error) {
 	cldrCoreZipFile, err := getCLDRCoreFile(path, version)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defer cldrCoreZipFile.Close()
 
 	zipFile, err := zip.OpenReader(cldrCoreZipFile.Name())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defer zipFile.Close()
 
 	models := make(map[string]*cldrLocaleModel)
+	supplemental := &cldrSupplementalData{}
 	for _, file := range zipFile.File {
 		fileInfo := file.FileInfo()
 		if strings.HasPrefix(file.Name, "common/main") && !fileInfo.IsDir() {
@@ -340,18 +688,18 @@ func readCLDRCoreFile(path string, version int) (map[string]*cldrLocaleModel, er
 				model := &LDML{}
 				entry, err := file.Open()
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				decoder := xml.NewDecoder(entry)
 				if err = decoder.Decode(model); err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				tag := fileInfo.Name()[:len(fileInfo.Name())-4]
 				parsedTag, err := language.Parse(tag)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				var parent string
@@ -361,10 +709,224 @@ func readCLDRCoreFile(path string, version int) (map[string]*cldrLocaleModel, er
 
 				models[parsedTag.String()] = &cldrLocaleModel{parent, model}
 			}
+		} else if strings.HasPrefix(file.Name, "common/supplemental") && !fileInfo.IsDir() {
+			err = supplemental.read(file)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+			}
+		}
+	}
+
+	return models, supplemental, nil
+}
+
+// cldrSupplementalData holds the CLDR supplemental data used by the generated tables.
+type cldrSupplementalData struct {
+	// dayPeriodRules maps the languages to the flexible day periods hours ranges.
+	dayPeriodRules map[string]map[string]string
+	// pluralRules maps the languages to the cardinal plural categories rules.
+	pluralRules map[string]map[string]string
+	// numberingSystems maps the numeric numbering systems to their digits, from zero to nine.
+	numberingSystems map[string]string
+	// metaZoneLocations maps the metazones to their golden zones, the time zones used
+	// for the "001" territory.
+	metaZoneLocations map[string]string
+	// timeZoneIDs holds the time zones using metazones, sorted.
+	timeZoneIDs []string
+	// hourCycles maps the regions, and a few languages regions, to their preferred
+	// hour cycle pattern letter.
+	hourCycles map[string]string
+}
+
+func (c *cldrSupplementalData) read(file *zip.File) error {
+	switch path.Base(file.Name) {
+	case "dayPeriods.xml":
+		model := &SupplementalData{}
+		entry, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		defer entry.Close()
+
+		if err = xml.NewDecoder(entry).Decode(model); err != nil {
+			return err
+		}
+
+		c.dayPeriodRules = make(map[string]map[string]string)
+		for _, ruleSet := range model.DayPeriodRuleSet {
+			// the "selection" rules are meant for formatting messages
+			if ruleSet.Type != "" {
+				continue
+			}
+
+			for _, rules := range ruleSet.DayPeriodRules {
+				periods := make(map[string]string, len(rules.DayPeriodRule))
+				for _, rule := range rules.DayPeriodRule {
+					if rule.At != "" {
+						periods[rule.Type] = rule.At
+					} else {
+						periods[rule.Type] = rule.From + "-" + rule.Before
+					}
+				}
+
+				for _, lang := range strings.Fields(rules.Locales) {
+					c.dayPeriodRules[lang] = periods
+				}
+			}
+		}
+	case "plurals.xml":
+		model := &SupplementalData{}
+		entry, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		defer entry.Close()
+
+		if err = xml.NewDecoder(entry).Decode(model); err != nil {
+			return err
+		}
+
+		c.pluralRules = make(map[string]map[string]string)
+		for _, plurals := range model.Plurals {
+			if plurals.Type != "cardinal" {
+				continue
+			}
+
+			for _, pluralRules := range plurals.PluralRules {
+				rules := make(map[string]string, len(pluralRules.PluralRule))
+				for _, rule := range pluralRules.PluralRule {
+					// the rules samples are not needed
+					condition, _, _ := strings.Cut(rule.CharData, "@")
+					rules[rule.Count] = strings.TrimSpace(condition)
+				}
+
+				for _, lang := range strings.Fields(pluralRules.Locales) {
+					c.pluralRules[lang] = rules
+				}
+			}
 		}
+	case "supplementalData.xml":
+		model := &SupplementalData{}
+		entry, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		defer entry.Close()
+
+		if err = xml.NewDecoder(entry).Decode(model); err != nil {
+			return err
+		}
+
+		c.hourCycles = make(map[string]string)
+		for _, hours := range model.TimeData {
+			for _, region := range strings.Fields(hours.Regions) {
+				c.hourCycles[region] = hours.Preferred
+			}
+		}
+	case "numberingSystems.xml":
+		model := &SupplementalData{}
+		entry, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		defer entry.Close()
+
+		if err = xml.NewDecoder(entry).Decode(model); err != nil {
+			return err
+		}
+
+		c.numberingSystems = make(map[string]string)
+		for _, numberingSystem := range model.NumberingSystems {
+			// algorithmic numbering systems have no digits
+			if numberingSystem.Type != "numeric" || utf8.RuneCountInString(numberingSystem.Digits) != 10 {
+				continue
+			}
+
+			c.numberingSystems[numberingSystem.ID] = numberingSystem.Digits
+		}
+	case "metaZones.xml":
+		model := &SupplementalData{}
+		entry, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		defer entry.Close()
+
+		if err = xml.NewDecoder(entry).Decode(model); err != nil {
+			return err
+		}
+
+		if model.MetaZones == nil {
+			return nil
+		}
+
+		if model.MetaZones.MetazoneInfo != nil {
+			for _, timeZone := range model.MetaZones.MetazoneInfo.Timezone {
+				c.timeZoneIDs = append(c.timeZoneIDs, timeZone.Type)
+			}
+			slices.Sort(c.timeZoneIDs)
+		}
+
+		c.metaZoneLocations = make(map[string]string)
+		for _, mapTimezones := range model.MetaZones.MapTimezones {
+			if mapTimezones.Type != "metazones" {
+				continue
+			}
+
+			for _, mapZone := range mapTimezones.MapZone {
+				if mapZone.Territory == "001" {
+					c.metaZoneLocations[mapZone.Other] = mapZone.Type
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func sortedTmplDataEntries(values map[string]string) []*tmplDataEntry {
+	entries := make([]*tmplDataEntry, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		entries = append(entries, &tmplDataEntry{Key: key, Value: values[key]})
+	}
+
+	return entries
+}
+
+// lookupLanguageRules returns the supplemental rules of the tag language, which are
+// defined by the CLDR for languages, and a few scripts or regions variants.
+func lookupLanguageRules(languageRules map[string]map[string]string, tag language.Tag) map[string]string {
+	if rules, ok := languageRules[strings.ReplaceAll(tag.String(), "-", "_")]; ok {
+		return rules
 	}
 
-	return models, nil
+	base, _ := tag.Base()
+	if rules, ok := languageRules[base.String()]; ok {
+		return rules
+	}
+
+	return languageRules["root"]
+}
+
+// lookupHourCycle returns the preferred hour cycle of the tag region, or of its most
+// likely region, which is defined by the CLDR for regions, and a few languages regions.
+func lookupHourCycle(hourCycles map[string]string, tag language.Tag) string {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	if hourCycle, ok := hourCycles[base.String()+"_"+region.String()]; ok {
+		return hourCycle
+	}
+
+	if hourCycle, ok := hourCycles[region.String()]; ok {
+		return hourCycle
+	}
+
+	return hourCycles["001"]
 }
 
 func getCLDRCoreFile(path string, version int) (*os.File, error) {
@@ -420,6 +982,60 @@ type cldrLocaleData struct {
 	longMonthNames  map[string]string
 	shortMonthNames map[string]string
 	dayPeriods      map[string]string
+
+	// stand-alone context, the nominative forms used when the
+	// names are not part of a complete date (e.g. "październik").
+	standAloneLongDayNames    map[string]string
+	standAloneShortDayNames   map[string]string
+	standAloneLongMonthNames  map[string]string
+	standAloneShortMonthNames map[string]string
+
+	// CLDR "short" (e.g. "Mo") and "narrow" (e.g. "M") widths.
+	minDayNames      map[string]string
+	narrowDayNames   map[string]string
+	narrowMonthNames map[string]string
+
+	longEraNames   map[string]string
+	shortEraNames  map[string]string
+	narrowEraNames map[string]string
+
+	// flexible day periods (e.g. "de la tarde"), keyed by the CLDR
+	// day period types, and the hours ranges of each one of them.
+	longFlexibleDayPeriods   map[string]string
+	shortFlexibleDayPeriods  map[string]string
+	narrowFlexibleDayPeriods map[string]string
+	dayPeriodRules           map[string]string
+
+	// numberingSystem is the CLDR default numbering system identifier (e.g. "arab").
+	numberingSystem string
+
+	// time zones formats (e.g. "GMT{0}"), keyed by the timeZoneFormatsStd types, and
+	// the zones and metazones localized names and exemplar cities.
+	timeZoneFormats map[string]string
+	longZoneNames   map[zoneNameKey]string
+	shortZoneNames  map[zoneNameKey]string
+	exemplarCities  map[string]string
+
+	// relative times names (e.g. "day:-1" for "yesterday") and patterns (e.g.
+	// "day:past:other" for "{0} days ago"), keyed by the field and relative type.
+	longRelativeTimes   map[string]string
+	shortRelativeTimes  map[string]string
+	narrowRelativeTimes map[string]string
+	// pluralRules holds the cardinal plural categories rules (e.g. "one": "i = 1 and v = 0").
+	pluralRules map[string]string
+
+	// date and time formats patterns (e.g. "d MMMM y"), keyed by the formatLengthsStd
+	// lengths, and the region preferred hour cycle pattern letter (e.g. "H").
+	dateFormats     map[string]string
+	timeFormats     map[string]string
+	dateTimeFormats map[string]string
+	hourCycle       string
+}
+
+// zoneNameKey identifies a zone or metazone name by its type: generic, standard or daylight.
+type zoneNameKey struct {
+	zone string
+	kind string
 }
 
 func (g *cldrLocaleData) clone() cldrLocaleData {
@@ -429,9 +1045,50 @@ func (g *cldrLocaleData) clone() cldrLocaleData {
 		longMonthNames:  maps.Clone(g.longMonthNames),
 		shortMonthNames: maps.Clone(g.shortMonthNames),
 		dayPeriods:      maps.Clone(g.dayPeriods),
+
+		standAloneLongDayNames:    maps.Clone(g.standAloneLongDayNames),
+		standAloneShortDayNames:   maps.Clone(g.standAloneShortDayNames),
+		standAloneLongMonthNames:  maps.Clone(g.standAloneLongMonthNames),
+		standAloneShortMonthNames: maps.Clone(g.standAloneShortMonthNames),
+
+		minDayNames:      maps.Clone(g.minDayNames),
+		narrowDayNames:   maps.Clone(g.narrowDayNames),
+		narrowMonthNames: maps.Clone(g.narrowMonthNames),
+
+		longEraNames:   maps.Clone(g.longEraNames),
+		shortEraNames:  maps.Clone(g.shortEraNames),
+		narrowEraNames: maps.Clone(g.narrowEraNames),
+
+		longFlexibleDayPeriods:   maps.Clone(g.longFlexibleDayPeriods),
+		shortFlexibleDayPeriods:  maps.Clone(g.shortFlexibleDayPeriods),
+		narrowFlexibleDayPeriods: maps.Clone(g.narrowFlexibleDayPeriods),
+		dayPeriodRules:           maps.Clone(g.dayPeriodRules),
+
+		numberingSystem: g.numberingSystem,
+
+		timeZoneFormats: maps.Clone(g.timeZoneFormats),
+		longZoneNames:   maps.Clone(g.longZoneNames),
+		shortZoneNames:  maps.Clone(g.shortZoneNames),
+		exemplarCities:  maps.Clone(g.exemplarCities),
+
+		longRelativeTimes:   maps.Clone(g.longRelativeTimes),
+		shortRelativeTimes:  maps.Clone(g.shortRelativeTimes),
+		narrowRelativeTimes: maps.Clone(g.narrowRelativeTimes),
+		pluralRules:         maps.Clone(g.pluralRules),
+
+		dateFormats:     maps.Clone(g.dateFormats),
+		timeFormats:     maps.Clone(g.timeFormats),
+		dateTimeFormats: maps.Clone(g.dateTimeFormats),
+		hourCycle:       g.hourCycle,
 	}
 }
 
+func (g *cldrLocaleData) hasFlexibleDayPeriods() bool {
+	return g.longFlexibleDayPeriods != nil ||
+		g.shortFlexibleDayPeriods != nil ||
+		g.narrowFlexibleDayPeriods != nil
+}
+
 func (g *cldrLocaleData) isEmpty() bool {
 	return g.shortDayNames == nil &&
 		g.longDayNames == nil &&
@@ -465,12 +1122,60 @@ func (g *cldrLocaleData) isValid() bool {
 		return false
 	}
 
+	if g.standAloneShortDayNames != nil && len(g.standAloneShortDayNames) != 7 {
+		return false
+	}
+
+	if g.standAloneLongDayNames != nil && len(g.standAloneLongDayNames) != 7 {
+		return false
+	}
+
+	if g.standAloneShortMonthNames != nil && len(g.standAloneShortMonthNames) != 12 {
+		return false
+	}
+
+	if g.standAloneLongMonthNames != nil && len(g.standAloneLongMonthNames) != 12 {
+		return false
+	}
+
+	if g.minDayNames != nil && len(g.minDayNames) != 7 {
+		return false
+	}
+
+	if g.narrowDayNames != nil && len(g.narrowDayNames) != 7 {
+		return false
+	}
+
+	if g.narrowMonthNames != nil && len(g.narrowMonthNames) != 12 {
+		return false
+	}
+
+	if g.longEraNames != nil && len(g.longEraNames) != 2 {
+		return false
+	}
+
+	if g.shortEraNames != nil && len(g.shortEraNames) != 2 {
+		return false
+	}
+
+	if g.narrowEraNames != nil && len(g.narrowEraNames) != 2 {
+		return false
+	}
+
 	return true
 }
 
 type tablesTmplData struct {
-	CLDRVersion *int
-	Tables      []*tablesTmplDataItem
+	CLDRVersion       *int
+	Tables            []*tablesTmplDataItem
+	NumberingSystems  []*tmplDataEntry
+	MetaZoneLocations []*tmplDataEntry
+	TimeZoneIDs       []string
+}
+
+type tmplDataEntry struct {
+	Key   string
+	Value string
 }
 
 type tablesTmplDataItem struct {
@@ -481,6 +1186,41 @@ type tablesTmplDataItem struct {
 	ShortMonthNames []string
 	LongMonthNames  []string
 	DayPeriods      []string
+
+	StandAloneShortDaysNames  []string
+	StandAloneLongDaysNames   []string
+	StandAloneShortMonthNames []string
+	StandAloneLongMonthNames  []string
+
+	MinDaysNames     []string
+	NarrowDaysNames  []string
+	NarrowMonthNames []string
+
+	LongEraNames   []string
+	ShortEraNames  []string
+	NarrowEraNames []string
+
+	LongFlexibleDayPeriods   []string
+	ShortFlexibleDayPeriods  []string
+	NarrowFlexibleDayPeriods []string
+	DayPeriodRules           []string
+
+	NumberingSystem string
+
+	TimeZoneFormats []string
+	LongZoneNames   []string
+	ShortZoneNames  []string
+	ExemplarCities  []string
+
+	LongRelativeTimes   []string
+	ShortRelativeTimes  []string
+	NarrowRelativeTimes []string
+	PluralRules         []string
+
+	DateFormats     []string
+	TimeFormats     []string
+	DateTimeFormats []string
+	HourCycle       string
 }
 
 func newTablesTmplDataItem(tag string, data *cldrLocaleData) *tablesTmplDataItem {
@@ -495,6 +1235,41 @@ func newTablesTmplDataItem(tag string, data *cldrLocaleData) *tablesTmplDataItem
 		ShortMonthNames: sortTableValues(data.shortMonthNames, shortMonthNamesStd),
 		LongMonthNames:  sortTableValues(data.longMonthNames, longMonthNamesStd),
 		DayPeriods:      sortTableValues(data.dayPeriods, dayPeriodsStd),
+
+		StandAloneShortDaysNames:  sortTableValues(data.standAloneShortDayNames, shortDayNamesStd),
+		StandAloneLongDaysNames:   sortTableValues(data.standAloneLongDayNames, longDayNamesStd),
+		StandAloneShortMonthNames: sortTableValues(data.standAloneShortMonthNames, shortMonthNamesStd),
+		StandAloneLongMonthNames:  sortTableValues(data.standAloneLongMonthNames, longMonthNamesStd),
+
+		MinDaysNames:     sortTableValues(data.minDayNames, shortDayNamesStd),
+		NarrowDaysNames:  sortTableValues(data.narrowDayNames, shortDayNamesStd),
+		NarrowMonthNames: sortTableValues(data.narrowMonthNames, shortMonthNamesStd),
+
+		LongEraNames:   sortTableValues(data.longEraNames, erasStd),
+		ShortEraNames:  sortTableValues(data.shortEraNames, erasStd),
+		NarrowEraNames: sortTableValues(data.narrowEraNames, erasStd),
+
+		LongFlexibleDayPeriods:   sortTableValues(data.longFlexibleDayPeriods, flexibleDayPeriodsStd),
+		ShortFlexibleDayPeriods:  sortTableValues(data.shortFlexibleDayPeriods, flexibleDayPeriodsStd),
+		NarrowFlexibleDayPeriods: sortTableValues(data.narrowFlexibleDayPeriods, flexibleDayPeriodsStd),
+		DayPeriodRules:           sortTableValues(data.dayPeriodRules, flexibleDayPeriodsStd),
+
+		NumberingSystem: data.numberingSystem,
+
+		TimeZoneFormats: sortTableValues(data.timeZoneFormats, timeZoneFormatsStd),
+		LongZoneNames:   zoneNamesTableValues(data.longZoneNames),
+		ShortZoneNames:  zoneNamesTableValues(data.shortZoneNames),
+		ExemplarCities:  pairsTableValues(data.exemplarCities),
+
+		LongRelativeTimes:   pairsTableValues(data.longRelativeTimes),
+		ShortRelativeTimes:  pairsTableValues(data.shortRelativeTimes),
+		NarrowRelativeTimes: pairsTableValues(data.narrowRelativeTimes),
+		PluralRules:         pairsTableValues(data.pluralRules),
+
+		DateFormats:     sortTableValues(data.dateFormats, formatLengthsStd),
+		TimeFormats:     sortTableValues(data.timeFormats, formatLengthsStd),
+		DateTimeFormats: sortTableValues(data.dateTimeFormats, formatLengthsStd),
+		HourCycle:       data.hourCycle,
 	}
 }
 
@@ -511,6 +1286,42 @@ func sortTableValues(table map[string]string, keys []string) []string {
 	return sorted
 }
 
+// zoneNamesTableValues flattens the zones names into zone and name pairs, sorted by
+// zone, and by the generic, standard and daylight types.
+func zoneNamesTableValues(names map[zoneNameKey]string) []string {
+	if names == nil {
+		return []string{}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(names), func(a, b zoneNameKey) int {
+		if c := strings.Compare(a.zone, b.zone); c != 0 {
+			return c
+		}
+		return slices.Index(zoneNameKinds, a.kind) - slices.Index(zoneNameKinds, b.kind)
+	})
+
+	values := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		values = append(values, key.zone, names[key])
+	}
+
+	return values
+}
+
+// pairsTableValues flattens the table into key and value pairs, sorted by key.
+func pairsTableValues(table map[string]string) []string {
+	if table == nil {
+		return []string{}
+	}
+
+	values := make([]string, 0, len(table)*2)
+	for _, key := range slices.Sorted(maps.Keys(table)) {
+		values = append(values, key, table[key])
+	}
+
+	return values
+}
+
 func stringSliceValue(values []string) string {
 	if values == nil {
 		return ""
@@ -527,10 +1338,13 @@ func stringSliceValue(values []string) string {
 	return sb.String()
 }
 
-func writeTableGoFile(cldrVersion *int, tables []*tablesTmplDataItem) error {
+func writeTableGoFile(cldrVersion *int, tables []*tablesTmplDataItem, supplemental *cldrSupplementalData) error {
 	data := tablesTmplData{
-		CLDRVersion: cldrVersion,
-		Tables:      tables,
+		CLDRVersion:       cldrVersion,
+		Tables:            tables,
+		NumberingSystems:  sortedTmplDataEntries(supplemental.numberingSystems),
+		MetaZoneLocations: sortedTmplDataEntries(supplemental.metaZoneLocations),
+		TimeZoneIDs:       supplemental.timeZoneIDs,
 	}
 
 	tablesTmpl := filepath.Join("templates", "tables.go.tmpl")
@@ -667,7 +1481,34 @@ type LDML struct {
 			Common
 			Calendar []*Calendar `xml:"calendar"`
 		} `xml:"calendars"`
+		TimeZoneNames *struct {
+			Common
+			HourFormat    []*Common   `xml:"hourFormat"`
+			GmtFormat     []*Common   `xml:"gmtFormat"`
+			GmtZeroFormat []*Common   `xml:"gmtZeroFormat"`
+			RegionFormat  []*Common   `xml:"regionFormat"`
+			Zone          []*TimeZone `xml:"zone"`
+			Metazone      []*TimeZone `xml:"metazone"`
+		} `xml:"timeZoneNames"`
+		Fields *struct {
+			Common
+			Field []*struct {
+				Common
+				Relative     []*Common `xml:"relative"`
+				RelativeTime []*struct {
+					Common
+					RelativeTimePattern []*struct {
+						Common
+						Count string `xml:"count,attr"`
+					} `xml:"relativeTimePattern"`
+				} `xml:"relativeTime"`
+			} `xml:"field"`
+		} `xml:"fields"`
 	} `xml:"dates"`
+	Numbers *struct {
+		Common
+		DefaultNumberingSystem []*Common `xml:"defaultNumberingSystem"`
+	} `xml:"numbers"`
 }
 
 // Calendar specifies the fields used for formatting and parsing dates and times.
@@ -703,6 +1544,106 @@ type Calendar struct {
 			} `xml:"dayPeriodWidth"`
 		} `xml:"dayPeriodContext"`
 	} `xml:"dayPeriods"`
+	Eras *struct {
+		Common
+		EraNames  *EraWidth `xml:"eraNames"`
+		EraAbbr   *EraWidth `xml:"eraAbbr"`
+		EraNarrow *EraWidth `xml:"eraNarrow"`
+	} `xml:"eras"`
+	DateFormats *struct {
+		Common
+		FormatLength []*FormatLength `xml:"dateFormatLength"`
+	} `xml:"dateFormats"`
+	TimeFormats *struct {
+		Common
+		FormatLength []*FormatLength `xml:"timeFormatLength"`
+	} `xml:"timeFormats"`
+	DateTimeFormats *struct {
+		Common
+		FormatLength []*FormatLength `xml:"dateTimeFormatLength"`
+	} `xml:"dateTimeFormats"`
+}
+
+// SupplementalData is the top-level type for the CLDR supplemental data files.
+type SupplementalData struct {
+	Common
+	DayPeriodRuleSet []*struct {
+		Common
+		DayPeriodRules []*struct {
+			Common
+			Locales       string `xml:"locales,attr"`
+			DayPeriodRule []*struct {
+				Common
+				At     string `xml:"at,attr"`
+				From   string `xml:"from,attr"`
+				Before string `xml:"before,attr"`
+			} `xml:"dayPeriodRule"`
+		} `xml:"dayPeriodRules"`
+	} `xml:"dayPeriodRuleSet"`
+	MetaZones *struct {
+		Common
+		MetazoneInfo *struct {
+			Common
+			Timezone []*Common `xml:"timezone"`
+		} `xml:"metazoneInfo"`
+		MapTimezones []*struct {
+			Common
+			MapZone []*struct {
+				Common
+				Other     string `xml:"other,attr"`
+				Territory string `xml:"territory,attr"`
+			} `xml:"mapZone"`
+		} `xml:"mapTimezones"`
+	} `xml:"metaZones"`
+	Plurals []*struct {
+		Common
+		PluralRules []*struct {
+			Common
+			Locales    string `xml:"locales,attr"`
+			PluralRule []*struct {
+				Common
+				Count string `xml:"count,attr"`
+			} `xml:"pluralRule"`
+		} `xml:"pluralRules"`
+	} `xml:"plurals"`
+	TimeData []*struct {
+		Common
+		Preferred string `xml:"preferred,attr"`
+		Regions   string `xml:"regions,attr"`
+	} `xml:"timeData>hours"`
+	NumberingSystems []*struct {
+		Common
+		ID     string `xml:"id,attr"`
+		Digits string `xml:"digits,attr"`
+	} `xml:"numberingSystems>numberingSystem"`
+}
+
+type TimeZone = struct {
+	Common
+	Long         *ZoneNames `xml:"long"`
+	Short        *ZoneNames `xml:"short"`
+	ExemplarCity []*Common  `xml:"exemplarCity"`
+}
+
+type ZoneNames = struct {
+	Common
+	Generic  []*Common `xml:"generic"`
+	Standard []*Common `xml:"standard"`
+	Daylight []*Common `xml:"daylight"`
+}
+
+// FormatLength holds the date, time, or date time formats of a CLDR length.
+type FormatLength = struct {
+	Common
+	Format []*struct {
+		Common
+		Pattern []*Common `xml:"pattern"`
+	} `xml:",any"`
+}
+
+type EraWidth = struct {
+	Common
+	Era []*Common `xml:"era"`
 }
 
 type MonthWidth = struct {
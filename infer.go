@@ -0,0 +1,742 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// InferredLayout is a layout inferred from sample values.
+type InferredLayout struct {
+	// Layout is the inferred Go time layout.
+	Layout string
+	// Locales holds the languages whose names the layout matched, sorted. It's empty if
+	// the samples have no names, and no candidates languages were provided.
+	Locales []string
+	// Score is the ratio of the samples parsed by the layout, from 0 to 1.
+	Score float64
+	// Ambiguous reports whether the order of the numeric date fields could not be told
+	// by their values (e.g. "01/02/2024"), and was taken from the language conventions.
+	Ambiguous bool
+}
+
+// InferLayout infers the layouts of the sample values, and returns them sorted from the
+// most to the least likely. The samples are split into numbers, names and literals, and
+// the names are matched against the days, months, and day periods names of the languages
+// detected by [DetectLocale], among the candidates languages, or among all languages if
+// the candidates argument is empty.
+//
+// Numeric fields are told apart by their values ranges across all samples, for example,
+// "13/01/2024" and "01/02/2024" are both day, month, and year values. When the values do
+// not tell the order of the day and the month, it's taken from the language short date
+// format, or the year, month, and day order if the year comes first. If the language has
+// no short date format, such samples have no layout. Clocks, fractional seconds, numeric
+// time zones offsets, and time zones abbreviations are inferred as well.
+//
+// Samples with different structures result in different layouts, each one scored by the
+// ratio of the samples it parses. If no layout parses any sample, it returns an
+// ErrUndetectedLayout error.
+func InferLayout(samples []string, candidates []string) ([]InferredLayout, error) {
+	for _, lang := range candidates {
+		if _, ok := tableLoaders[lang]; !ok {
+			return nil, &ErrUnsupportedLocale{lang}
+		}
+	}
+
+	// the languages matching at least half the words of the best matching language are
+	// tried, as literal words (e.g. "de") might be names of other languages.
+	matches := make(map[string]int)
+	for _, sample := range samples {
+		ranked, _ := rankLocales(sample, candidates)
+		for _, r := range ranked {
+			matches[r.lang] += r.words
+		}
+	}
+
+	var langs []string
+	if len(matches) > 0 {
+		best := slices.Max(slices.Collect(maps.Values(matches)))
+		for _, lang := range slices.Sorted(maps.Keys(matches)) {
+			if matches[lang]*2 >= best {
+				langs = append(langs, lang)
+			}
+		}
+	} else if len(candidates) > 0 {
+		langs = candidates
+	} else {
+		langs = []string{LocaleEn}
+	}
+
+	locales := make([]Locale, 0, len(langs))
+	for _, lang := range langs {
+		locale, err := NewDefaultLocale(lang)
+		if err != nil {
+			return nil, err
+		}
+		locales = append(locales, locale)
+	}
+
+	layouts := inferLayouts(samples, locales, len(candidates) > 0)
+	if len(layouts) == 0 {
+		return nil, newUndetectedLayoutError(samples)
+	}
+
+	return layouts, nil
+}
+
+// inferLayouts infers the samples layouts for each one of the locales, merging the locales
+// inferring the same layouts. Layouts with no names are reported without locales, unless
+// the named argument is true, as the locales were explicitly provided.
+func inferLayouts(samples []string, locales []Locale, named bool) []InferredLayout {
+	var layouts []InferredLayout
+	for _, locale := range locales {
+		names := newInferNames(locale)
+		order := dateFieldsOrder(locale)
+
+		shapes := make(map[string]*inferShape)
+		var keys []string
+		for _, sample := range samples {
+			tokens := inferTokens(transliterateDigits(sample, locale), names)
+			key := inferShapeKey(tokens)
+			shape, ok := shapes[key]
+			if !ok {
+				shape = &inferShape{tokens: tokens, columns: make([][]inferToken, len(tokens))}
+				shapes[key] = shape
+				keys = append(keys, key)
+			}
+
+			for i, token := range tokens {
+				shape.columns[i] = append(shape.columns[i], token)
+			}
+		}
+
+		for _, key := range keys {
+			shape := shapes[key]
+			layout, ambiguous, ok := shape.layout(order)
+			if !ok || !shape.hasFields() {
+				continue
+			}
+
+			parsed := 0
+			for _, sample := range samples {
+				if _, err := parseValue(layout, nil, sample, nil, locale, &Options{}); err == nil {
+					parsed++
+				}
+			}
+
+			if parsed == 0 {
+				continue
+			}
+
+			var lang string
+			if named || shape.hasNames() {
+				lang = locale.Language()
+			}
+
+			layouts = mergeInferredLayout(layouts, InferredLayout{
+				Layout:    layout,
+				Score:     float64(parsed) / float64(len(samples)),
+				Ambiguous: ambiguous,
+			}, lang)
+		}
+	}
+
+	slices.SortStableFunc(layouts, func(a, b InferredLayout) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		if a.Ambiguous != b.Ambiguous {
+			if a.Ambiguous {
+				return 1
+			}
+			return -1
+		}
+		if len(a.Locales) != len(b.Locales) {
+			return len(b.Locales) - len(a.Locales)
+		}
+		return strings.Compare(a.Layout, b.Layout)
+	})
+
+	return layouts
+}
+
+// mergeInferredLayout appends the language to the locales of the layouts item equal to the
+// inferred one, or appends the inferred layout with the language if there's none.
+func mergeInferredLayout(layouts []InferredLayout, inferred InferredLayout, lang string) []InferredLayout {
+	i := slices.IndexFunc(layouts, func(l InferredLayout) bool {
+		return l.Layout == inferred.Layout && l.Score == inferred.Score && l.Ambiguous == inferred.Ambiguous
+	})
+	if i < 0 {
+		layouts = append(layouts, inferred)
+		i = len(layouts) - 1
+	}
+
+	if lang != "" && !slices.Contains(layouts[i].Locales, lang) {
+		layouts[i].Locales = append(layouts[i].Locales, lang)
+	}
+
+	return layouts
+}
+
+// dateFieldsOrder returns the order of the year (y), month (m), and day (d) numeric fields
+// of the locale short date format, or an empty string if it has none.
+func dateFieldsOrder(locale Locale) string {
+	if formats, ok := locale.(DateTimeFormatLocale); ok {
+		if pattern, ok := lookupFormat(formats.DateFormats(), Short); ok {
+			var order []byte
+			for _, token := range ldmlTokens(pattern) {
+				if !token.field {
+					continue
+				}
+
+				switch token.raw[0] {
+				case 'y', 'Y', 'u':
+					order = append(order, 'y')
+				case 'M', 'L':
+					order = append(order, 'm')
+				case 'd':
+					order = append(order, 'd')
+				}
+			}
+
+			if len(order) == 3 {
+				return string(order)
+			}
+		}
+	}
+
+	return ""
+}
+
+// inferTokenKind is the kind of the samples tokens.
+type inferTokenKind byte
+
+const (
+	literalToken inferTokenKind = iota
+	digitsToken
+	nameToken
+	zoneToken
+	signToken
+)
+
+// inferToken is a sample token, holding the matched name fields for name tokens.
+type inferToken struct {
+	kind   inferTokenKind
+	text   string
+	fields nameFields
+}
+
+// nameFields is a set of the layout names elements, sorted by preference.
+type nameFields uint8
+
+const (
+	longMonthField nameFields = 1 << iota
+	shortMonthField
+	longDayField
+	shortDayField
+	dayPeriodField
+)
+
+// inferNameElems holds the layout elements of the name fields, sorted as the fields.
+var inferNameElems = []string{"January", "Jan", "Monday", "Mon", "PM"}
+
+// inferNames holds the locale names tables of each name field, sorted as the fields.
+type inferNames [][][]string
+
+func newInferNames(locale Locale) inferNames {
+	opts := &Options{}
+	return inferNames{
+		monthNamesTabs(nil, locale, true, opts),
+		monthNamesTabs(nil, locale, false, opts),
+		dayNamesTabs(nil, locale, true, opts),
+		dayNamesTabs(nil, locale, false, opts),
+		{locale.DayPeriods()},
+	}
+}
+
+// match returns the length of the longest name matching the value at the offset, and the
+// fields having a name of that length. Names followed by cased letters are ignored, as
+// they are part of a longer word, and so are single cased letters names (e.g. the "T"
+// of ISO 8601 values), which are too ambiguous to tell a field. Single letters names are
+// ignored after digits as well, as they are likely units (e.g. the "月" of "10月").
+func (n inferNames) match(value string, offset int, afterDigits bool) (int, nameFields) {
+	var length int
+	var fields nameFields
+	for i, tabs := range n {
+		_, _, index, matched, _ := lookup(offset, value, tabs...)
+		if index < 0 || len(matched) == 0 || len(matched) < length {
+			continue
+		}
+
+		if r, _ := utf8.DecodeRuneInString(value[offset+len(matched):]); isCased(r) {
+			continue
+		}
+
+		if r, size := utf8.DecodeRuneInString(matched); size == len(matched) && (afterDigits || isCased(r)) {
+			continue
+		}
+
+		if len(matched) > length {
+			length, fields = len(matched), 0
+		}
+		fields |= 1 << i
+	}
+
+	return length, fields
+}
+
+// inferTokens splits the value into digits, names, time zones abbreviations, time zones
+// offsets signs, and literals tokens.
+func inferTokens(value string, names inferNames) []inferToken {
+	var tokens []inferToken
+	// clock reports whether a clock (e.g. "15:04") was read, so a following minus sign
+	// is the time zone offset sign instead of a literal.
+	clock := false
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		switch {
+		case isDigit(value, i):
+			end := i
+			for isDigit(value, end) {
+				end++
+			}
+
+			if n := len(tokens); n >= 2 && tokens[n-1].text == ":" && tokens[n-2].kind == digitsToken {
+				clock = true
+			}
+			tokens = append(tokens, inferToken{kind: digitsToken, text: value[i:end]})
+			i = end
+		case (r == '+' || (r == '-' && clock)) && isDigit(value, i+1):
+			tokens = append(tokens, inferToken{kind: signToken, text: value[i : i+1]})
+			i++
+		case unicode.IsLetter(r):
+			afterDigits := len(tokens) > 0 && tokens[len(tokens)-1].kind == digitsToken
+			if n, fields := names.match(value, i, afterDigits); n > 0 {
+				tokens = append(tokens, inferToken{kind: nameToken, text: value[i : i+n], fields: fields})
+				i += n
+				break
+			}
+
+			end := i
+			for end < len(value) {
+				r, size := utf8.DecodeRuneInString(value[end:])
+				if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+					break
+				}
+				end += size
+			}
+
+			if word := value[i:end]; isZoneAbbreviation(word) {
+				tokens = append(tokens, inferToken{kind: zoneToken, text: word})
+			} else {
+				tokens = appendLiteralToken(tokens, word)
+			}
+			i = end
+		default:
+			tokens = appendLiteralToken(tokens, value[i:i+size])
+			i += size
+		}
+	}
+
+	return tokens
+}
+
+func isCased(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsLower(r)
+}
+
+func appendLiteralToken(tokens []inferToken, literal string) []inferToken {
+	if n := len(tokens); n > 0 && tokens[n-1].kind == literalToken {
+		tokens[n-1].text += literal
+		return tokens
+	}
+	return append(tokens, inferToken{kind: literalToken, text: literal})
+}
+
+func isDigit(value string, i int) bool {
+	return i < len(value) && value[i] >= '0' && value[i] <= '9'
+}
+
+// isZoneAbbreviation reports whether the word looks like a time zone abbreviation, as
+// the ones parsed by the MST layout element (e.g. "UTC", "CEST").
+func isZoneAbbreviation(word string) bool {
+	if len(word) < 3 || len(word) > 5 {
+		return false
+	}
+
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'A' || word[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// inferShapeKey returns a key identifying the samples sharing the tokens structure, which
+// are the same kinds of tokens, and the same literals.
+func inferShapeKey(tokens []inferToken) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte(byte(token.kind))
+		if token.kind == literalToken {
+			sb.WriteString(token.text)
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+// inferShape holds the tokens of the samples sharing the same structure, by position.
+type inferShape struct {
+	tokens  []inferToken
+	columns [][]inferToken
+}
+
+func (s *inferShape) hasNames() bool {
+	return slices.ContainsFunc(s.tokens, func(t inferToken) bool { return t.kind == nameToken })
+}
+
+func (s *inferShape) hasFields() bool {
+	return slices.ContainsFunc(s.tokens, func(t inferToken) bool { return t.kind != literalToken })
+}
+
+// numericColumn holds the widths and values ranges of a digits tokens column.
+type numericColumn struct {
+	pos                int
+	minWidth, maxWidth int
+	minValue, maxValue int
+}
+
+func (s *inferShape) numericColumn(pos int) numericColumn {
+	c := numericColumn{pos: pos, minWidth: -1, minValue: -1}
+	for _, token := range s.columns[pos] {
+		v, _ := strconv.Atoi(token.text)
+		if c.minWidth < 0 || len(token.text) < c.minWidth {
+			c.minWidth = len(token.text)
+		}
+		if c.minValue < 0 || v < c.minValue {
+			c.minValue = v
+		}
+		c.maxWidth = max(c.maxWidth, len(token.text))
+		c.maxValue = max(c.maxValue, v)
+	}
+	return c
+}
+
+// padded returns the zero-padded layout element if all the column values have two digits,
+// or the unpadded one otherwise.
+func (c numericColumn) padded(paddedElem, elem string) string {
+	if c.minWidth == 2 {
+		return paddedElem
+	}
+	return elem
+}
+
+// layout returns the layout of the shape, and whether the date fields order was taken from
+// the order argument. It returns false if the shape can't be represented by a layout.
+func (s *inferShape) layout(order string) (string, bool, bool) {
+	elems := make([]string, len(s.tokens))
+	var period bool
+	var dates []numericColumn
+	var monthName bool
+
+	for i, token := range s.tokens {
+		switch token.kind {
+		case literalToken:
+			elems[i] = token.text
+		case zoneToken:
+			elems[i] = "MST"
+		case nameToken:
+			fields := token.fields
+			for _, t := range s.columns[i] {
+				fields &= t.fields
+			}
+
+			if fields == 0 {
+				return "", false, false
+			}
+
+			// the preferred field is the first one in the set
+			field := 0
+			for fields&(1<<field) == 0 {
+				field++
+			}
+
+			elems[i] = inferNameElems[field]
+			period = period || nameFields(1<<field) == dayPeriodField
+			monthName = monthName || nameFields(1<<field)&(longMonthField|shortMonthField) != 0
+		}
+	}
+
+	for i := 0; i < len(s.tokens); i++ {
+		if s.tokens[i].kind != digitsToken || elems[i] != "" {
+			continue
+		}
+
+		// time zone offsets: -07, -0700, or -07:00
+		if i > 0 && s.tokens[i-1].kind == signToken {
+			switch c := s.numericColumn(i); {
+			case c.minWidth == 4 && c.maxWidth == 4:
+				elems[i] = "-0700"
+			case c.minWidth == 2 && c.maxWidth == 2 && s.isClockSeparator(i+1) && s.isDigits(i+2, 2, 2):
+				elems[i], elems[i+2] = "-07", "00"
+			case c.minWidth == 2 && c.maxWidth == 2:
+				elems[i] = "-07"
+			default:
+				return "", false, false
+			}
+			continue
+		}
+
+		// clocks: hours and minutes, followed by optional seconds and fractional seconds
+		if s.isClockSeparator(i+1) && s.isDigits(i+2, 1, 2) {
+			hour := s.numericColumn(i)
+			if hour.maxWidth > 2 {
+				return "", false, false
+			}
+
+			if period {
+				if hour.minValue < 1 || hour.maxValue > 12 {
+					return "", false, false
+				}
+				elems[i] = hour.padded("03", "3")
+			} else {
+				if hour.maxValue > 23 {
+					return "", false, false
+				}
+				elems[i] = "15"
+			}
+
+			minute := s.numericColumn(i + 2)
+			if minute.maxValue > 59 {
+				return "", false, false
+			}
+			elems[i+2] = minute.padded("04", "4")
+			i += 2
+
+			if s.isClockSeparator(i+1) && s.isDigits(i+2, 1, 2) {
+				second := s.numericColumn(i + 2)
+				if second.maxValue > 60 {
+					return "", false, false
+				}
+				elems[i+2] = second.padded("05", "5")
+				i += 2
+
+				if sep := s.literal(i + 1); (sep == "." || sep == ",") && s.isDigits(i+2, 1, 9) {
+					fraction := s.numericColumn(i + 2)
+					if fraction.minWidth == fraction.maxWidth {
+						elems[i+2] = strings.Repeat("0", fraction.minWidth)
+					} else {
+						elems[i+2] = strings.Repeat("9", 9)
+					}
+					i += 2
+				}
+			}
+			continue
+		}
+
+		// hours with no minutes are followed by a day period (e.g. "3 PM")
+		if period && s.isDayPeriodNext(i) {
+			hour := s.numericColumn(i)
+			if hour.maxWidth > 2 || hour.minValue < 1 || hour.maxValue > 12 {
+				return "", false, false
+			}
+			elems[i] = hour.padded("03", "3")
+			continue
+		}
+
+		dates = append(dates, s.numericColumn(i))
+	}
+
+	dateElems, ambiguous, ok := inferDateFields(dates, monthName, order)
+	if !ok {
+		return "", false, false
+	}
+
+	for i, elem := range dateElems {
+		elems[dates[i].pos] = elem
+	}
+
+	return strings.Join(elems, ""), ambiguous, true
+}
+
+func (s *inferShape) literal(pos int) string {
+	if pos >= len(s.tokens) || s.tokens[pos].kind != literalToken {
+		return ""
+	}
+	return s.tokens[pos].text
+}
+
+func (s *inferShape) isClockSeparator(pos int) bool {
+	return s.literal(pos) == ":"
+}
+
+// isDigits reports whether the token at the position is a digits token, with all its
+// values widths in the given range.
+func (s *inferShape) isDigits(pos int, minWidth, maxWidth int) bool {
+	if pos >= len(s.tokens) || s.tokens[pos].kind != digitsToken {
+		return false
+	}
+
+	c := s.numericColumn(pos)
+	return c.minWidth >= minWidth && c.maxWidth <= maxWidth
+}
+
+// isDayPeriodNext reports whether the token at the position is followed by a day period
+// name, with only spaces between them.
+func (s *inferShape) isDayPeriodNext(pos int) bool {
+	next := pos + 1
+	if strings.TrimSpace(s.literal(next)) == "" && s.literal(next) != "" {
+		next++
+	}
+
+	return next < len(s.tokens) && s.tokens[next].kind == nameToken && s.tokens[next].fields&dayPeriodField != 0
+}
+
+// inferDateFields returns the layout elements of the numeric date columns, and whether
+// the month and day order was taken from the order argument. It returns false if the
+// order is needed, but empty.
+func inferDateFields(columns []numericColumn, monthName bool, order string) ([]string, bool, bool) {
+	if len(columns) > 3 {
+		return nil, false, false
+	}
+
+	roles := make([]byte, len(columns))
+
+	// four digits values are years, otherwise, values greater than 31 are
+	year := slices.IndexFunc(columns, func(c numericColumn) bool { return c.minWidth == 4 && c.maxWidth == 4 })
+	if year < 0 {
+		year = slices.IndexFunc(columns, func(c numericColumn) bool { return c.maxValue > 31 })
+	}
+	if year >= 0 {
+		roles[year] = 'y'
+	}
+
+	var rest []int
+	for i := range columns {
+		if roles[i] == 0 {
+			rest = append(rest, i)
+		}
+	}
+
+	ambiguous := false
+	switch {
+	case monthName && len(rest) == 1:
+		roles[rest[0]] = 'd'
+	case monthName && len(rest) == 2 && year < 0:
+		// e.g. "15 Oct 24", the day usually precedes the year
+		roles[rest[0]], roles[rest[1]] = 'd', 'y'
+		ambiguous = true
+	case monthName && len(rest) > 0:
+		return nil, false, false
+	case len(rest) == 3 && year < 0:
+		// no year was told apart, so all fields follow the order
+		if order == "" {
+			return nil, false, false
+		}
+		for i, role := range []byte(order) {
+			roles[rest[i]] = role
+		}
+		month, day := slices.Index(roles, 'm'), slices.Index(roles, 'd')
+		if columns[month].maxValue > 12 && columns[day].maxValue <= 12 {
+			roles[month], roles[day] = 'd', 'm'
+		}
+		ambiguous = true
+	case len(rest) == 2:
+		a, b := columns[rest[0]], columns[rest[1]]
+		switch {
+		case a.maxValue > 12 && b.maxValue <= 12:
+			roles[rest[0]], roles[rest[1]] = 'd', 'm'
+		case a.maxValue <= 12 && b.maxValue > 12:
+			roles[rest[0]], roles[rest[1]] = 'm', 'd'
+		case a.maxValue > 12:
+			return nil, false, false
+		case year >= 0 && year < rest[0] || strings.Index(order, "m") < strings.Index(order, "d"):
+			// years first are followed by the month, as in ISO 8601
+			roles[rest[0]], roles[rest[1]] = 'm', 'd'
+			ambiguous = true
+		case order == "":
+			return nil, false, false
+		default:
+			roles[rest[0]], roles[rest[1]] = 'd', 'm'
+			ambiguous = true
+		}
+	case len(rest) == 1 && year >= 0:
+		roles[rest[0]] = 'm'
+	case len(rest) == 1:
+		roles[rest[0]] = 'd'
+	}
+
+	elems := make([]string, len(columns))
+	for i, c := range columns {
+		switch roles[i] {
+		case 'y':
+			switch {
+			case c.minWidth == 4 && c.maxWidth == 4:
+				elems[i] = "2006"
+			case c.minWidth == 2 && c.maxWidth == 2:
+				elems[i] = "06"
+			default:
+				return nil, false, false
+			}
+		case 'm':
+			if c.maxWidth > 2 || c.minValue < 1 || c.maxValue > 12 {
+				return nil, false, false
+			}
+			elems[i] = c.padded("01", "1")
+		case 'd':
+			if c.maxWidth > 2 || c.minValue < 1 || c.maxValue > 31 {
+				return nil, false, false
+			}
+			elems[i] = c.padded("02", "2")
+		}
+	}
+
+	return elems, ambiguous, true
+}
+
+// ErrUndetectedLayout indicates that no layout parses the provided samples.
+type ErrUndetectedLayout struct {
+	Samples []string
+}
+
+func (u *ErrUndetectedLayout) Error() string {
+	return fmt.Sprintf(`no layout parses the samples "%s"`, strings.Join(u.Samples, `", "`))
+}
+
+func (u *ErrUndetectedLayout) Is(err error) bool {
+	var target *ErrUndetectedLayout
+	if ok := errors.As(err, &target); ok {
+		return slices.Equal(u.Samples, target.Samples)
+	}
+	return false
+}
+
+func newUndetectedLayoutError(samples []string) error {
+	return &ErrUndetectedLayout{
+		Samples: samples,
+	}
+}
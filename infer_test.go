@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestInferLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		samples    []string
+		candidates []string
+		layout     string
+		locale     string
+		ambiguous  bool
+	}{
+		{name: "LongMonthNames", samples: []string{"15 de octubre de 2024", "1 de mayo de 2023", "3 de enero de 2022"}, layout: "2 de January de 2006", locale: LocaleEs},
+		{name: "DayAndMonthNames", samples: []string{"mardi 15 octobre 2024 21:41", "lundi 1 janvier 2024 08:05"}, layout: "Monday 2 January 2006 15:04", locale: LocaleFr},
+		{name: "DayPeriods", samples: []string{"Oct 15, 2024 9:41 PM", "Jan 5, 2024 11:02 AM"}, layout: "Jan 2, 2006 3:04 PM", locale: LocaleEn},
+		{name: "TimeZoneNames", samples: []string{"Tue, 15 Oct 2024 21:41:30 CEST", "Wed, 16 Oct 2024 01:41:30 UTC"}, layout: "Mon, 02 Jan 2006 15:04:05 MST", locale: LocaleEn},
+		{name: "DayMonthYear", samples: []string{"13/01/2024", "01/02/2024"}, layout: "02/01/2006"},
+		{name: "MonthDayYear", samples: []string{"01/13/2024", "02/01/2024"}, layout: "01/02/2006"},
+		{name: "YearFirst", samples: []string{"2024-01-02"}, layout: "2006-01-02", ambiguous: true},
+		{name: "TwoDigitsYear", samples: []string{"15.10.99", "1.2.24"}, layout: "2.1.06"},
+		{name: "Offsets", samples: []string{"2024-10-15 21:41:30.123 +0200", "2024-01-05 01:02:03.456 -0500"}, layout: "2006-01-02 15:04:05.000 -0700"},
+		{name: "ISO8601", samples: []string{"2024-10-15T21:41:30Z", "2024-10-16T01:41:30Z"}, layout: "2006-01-02T15:04:05Z"},
+		{name: "ISO8601Offsets", samples: []string{"2024-10-15T21:41:30.5+02:00", "2024-10-16T01:41:30.25-05:00"}, layout: "2006-01-02T15:04:05.999999999-07:00"},
+		{name: "FullWidthDigits", samples: []string{"２０２４年１０月１５日"}, candidates: []string{LocaleJa}, layout: "2006年01月02日", locale: LocaleJa},
+		{name: "Candidates", samples: []string{"15/10/2024"}, candidates: []string{LocaleEs}, layout: "02/01/2006", locale: LocaleEs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layouts, err := InferLayout(tt.samples, tt.candidates)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			got := layouts[0]
+			if got.Layout != tt.layout {
+				t.Errorf("expected layout: '%s', got: '%s'", tt.layout, got.Layout)
+			}
+
+			if tt.locale == "" && len(got.Locales) > 0 {
+				t.Errorf("expected no languages, got: %v", got.Locales)
+			}
+
+			if tt.locale != "" && !slices.Contains(got.Locales, tt.locale) {
+				t.Errorf("expected language %s, got: %v", tt.locale, got.Locales)
+			}
+
+			if got.Ambiguous != tt.ambiguous {
+				t.Errorf("expected ambiguous: %v, got: %v", tt.ambiguous, got.Ambiguous)
+			}
+		})
+	}
+
+	t.Run("Scores", func(t *testing.T) {
+		layouts, err := InferLayout([]string{"2024-10-15", "2024-10-16", "2024-10-17", "15/10/2024"}, nil)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		if len(layouts) != 2 {
+			t.Fatalf("expected 2 layouts, got: %+v", layouts)
+		}
+
+		if layouts[0].Layout != "2006-01-02" || layouts[0].Score != 0.75 {
+			t.Errorf("expected layout '2006-01-02' with score 0.75, got: %+v", layouts[0])
+		}
+
+		if layouts[1].Layout != "02/01/2006" || layouts[1].Score != 0.25 {
+			t.Errorf("expected layout '02/01/2006' with score 0.25, got: %+v", layouts[1])
+		}
+	})
+
+	t.Run("Undetected", func(t *testing.T) {
+		samples := []string{"foo", "1234567890"}
+		_, err := InferLayout(samples, nil)
+		if expected := newUndetectedLayoutError(samples); !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("UnsupportedLocale", func(t *testing.T) {
+		_, err := InferLayout([]string{"2024-10-15"}, []string{"zz"})
+		var e *ErrUnsupportedLocale
+		if !errors.As(err, &e) || e.lang != "zz" {
+			t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"zz"}, err)
+		}
+	})
+}
+
+func TestInferLayoutDateFieldsOrder(t *testing.T) {
+	es := newTestLocale(LocaleEs, map[int][]string{dateFormatsField: {"EEEE, d 'de' MMMM 'de' y", "d 'de' MMMM 'de' y", "d MMM y", "d/M/yy"}})
+
+	layouts := inferLayouts([]string{"01/02/2024"}, []Locale{es}, true)
+	if len(layouts) != 1 {
+		t.Fatalf("expected 1 layout, got: %+v", layouts)
+	}
+
+	want := InferredLayout{Layout: "02/01/2006", Locales: []string{LocaleEs}, Score: 1, Ambiguous: true}
+	if got := layouts[0]; got.Layout != want.Layout || !slices.Equal(got.Locales, want.Locales) || got.Ambiguous != want.Ambiguous {
+		t.Errorf("expected: %+v, got: %+v", want, got)
+	}
+
+	// the values tell the order, regardless of the locale
+	layouts = inferLayouts([]string{"01/13/2024"}, []Locale{es}, true)
+	if len(layouts) != 1 || layouts[0].Layout != "01/02/2006" || layouts[0].Ambiguous {
+		t.Errorf("expected layout '01/02/2006', got: %+v", layouts)
+	}
+
+	// the order is not guessed if the locale has no date formats
+	noFormats := newTestLocale(LocaleEs, map[int][]string{dateFormatsField: nil})
+	for _, samples := range [][]string{{"01/02/2024"}, {"01/02/24"}} {
+		if layouts = inferLayouts(samples, []Locale{noFormats}, true); len(layouts) != 0 {
+			t.Errorf("%v: expected no layouts, got: %+v", samples, layouts)
+		}
+	}
+
+	layouts = inferLayouts([]string{"2024-01-02"}, []Locale{noFormats}, true)
+	if len(layouts) != 1 || layouts[0].Layout != "2006-01-02" {
+		t.Errorf("expected layout '2006-01-02', got: %+v", layouts)
+	}
+}
+
+func TestInferLayoutDateFieldsOrderLocales(t *testing.T) {
+	tests := []struct {
+		lang   string
+		layout string
+	}{
+		{lang: LocaleEn, layout: "01/02/2006"},
+		{lang: LocaleEs, layout: "02/01/2006"},
+		{lang: LocaleDe, layout: "02/01/2006"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, dateFormatsField)
+
+			layouts, err := InferLayout([]string{"01/02/2024"}, []string{tt.lang})
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got := layouts[0]; got.Layout != tt.layout || !got.Ambiguous {
+				t.Errorf("expected ambiguous layout '%s', got: %+v", tt.layout, got)
+			}
+		})
+	}
+}
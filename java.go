@@ -0,0 +1,286 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxJavaPatternAlternatives is the maximum number of patterns a Java pattern optional
+// sections can be expanded to.
+const maxJavaPatternAlternatives = 256
+
+// elasticsearchFormats maps the Elasticsearch built-in date formats names to the equivalent
+// Java patterns. Fractional seconds following the seconds are accepted by the parser, so
+// they're omitted. The week based formats are included to report their unsupported fields.
+var elasticsearchFormats = map[string]string{
+	"date_optional_time":                "yyyy[-MM[-dd]]['T'HH[:mm[:ss]][XXX]]",
+	"date_optional_time_nanos":          "yyyy[-MM[-dd]]['T'HH[:mm[:ss]][XXX]]",
+	"basic_date":                        "yyyyMMdd",
+	"basic_date_time":                   "yyyyMMdd'T'HHmmssXX",
+	"basic_date_time_no_millis":         "yyyyMMdd'T'HHmmssXX",
+	"basic_ordinal_date":                "yyyyDDD",
+	"basic_ordinal_date_time":           "yyyyDDD'T'HHmmssXX",
+	"basic_ordinal_date_time_no_millis": "yyyyDDD'T'HHmmssXX",
+	"basic_time":                        "HHmmssXX",
+	"basic_time_no_millis":              "HHmmssXX",
+	"basic_t_time":                      "'T'HHmmssXX",
+	"basic_t_time_no_millis":            "'T'HHmmssXX",
+	"basic_week_date":                   "YYYY'W'wwe",
+	"date":                              "yyyy-MM-dd",
+	"date_hour":                         "yyyy-MM-dd'T'HH",
+	"date_hour_minute":                  "yyyy-MM-dd'T'HH:mm",
+	"date_hour_minute_second":           "yyyy-MM-dd'T'HH:mm:ss",
+	"date_hour_minute_second_fraction":  "yyyy-MM-dd'T'HH:mm:ss",
+	"date_hour_minute_second_millis":    "yyyy-MM-dd'T'HH:mm:ss",
+	"date_time":                         "yyyy-MM-dd'T'HH:mm:ssXXX",
+	"date_time_no_millis":               "yyyy-MM-dd'T'HH:mm:ssXXX",
+	"hour":                              "HH",
+	"hour_minute":                       "HH:mm",
+	"hour_minute_second":                "HH:mm:ss",
+	"hour_minute_second_fraction":       "HH:mm:ss",
+	"hour_minute_second_millis":         "HH:mm:ss",
+	"ordinal_date":                      "yyyy-DDD",
+	"ordinal_date_time":                 "yyyy-DDD'T'HH:mm:ssXXX",
+	"ordinal_date_time_no_millis":       "yyyy-DDD'T'HH:mm:ssXXX",
+	"time":                              "HH:mm:ssXXX",
+	"time_no_millis":                    "HH:mm:ssXXX",
+	"t_time":                            "'T'HH:mm:ssXXX",
+	"t_time_no_millis":                  "'T'HH:mm:ssXXX",
+	"week_date":                         "YYYY-'W'ww-e",
+	"weekyear":                          "YYYY",
+	"weekyear_week":                     "YYYY-'W'ww",
+	"weekyear_week_day":                 "YYYY-'W'ww-e",
+	"year":                              "yyyy",
+	"year_month":                        "yyyy-MM",
+	"year_month_day":                    "yyyy-MM-dd",
+}
+
+// ParseJava parses a formatted string in foreign language using the Java DateTimeFormatter
+// pattern (e.g. "dd/MMM/yyyy:HH:mm:ss Z"), and returns the [time.Time] value it represents.
+// It behaves as the Elasticsearch and Logstash date configurations: the pattern can include
+// optional sections (e.g. "yyyy-MM-dd['T'HH:mm]"), can be an Elasticsearch built-in format
+// name (e.g. "strict_date_optional_time", or "epoch_millis"), and can combine several
+// patterns separated by "||", which are tried in order.
+//
+// Both the year (u) and year of era (y) fields are parsed as years of the common era, and
+// the two digits years are parsed in the 2000-2099 range. Pattern fields with no layout
+// equivalent (see [LDMLToLayout]) result in an ErrUnsupportedPatternElem error. In the
+// absence of time zone information, it returns a time in UTC.
+//
+// The language argument must be a well-formed BCP 47 language tag, e.g ("en", "en-US") and
+// a known locale. If no data is found for the language, it returns ErrUnsupportedLocale.
+//
+// To execute several parses for the same locale, use [ParseJavaInLocationWithLocale] as it performs better.
+func ParseJava(pattern string, value string, lang string) (time.Time, error) {
+	return ParseJavaInLocation(pattern, value, lang, time.UTC)
+}
+
+// ParseJavaInLocation is like ParseJava but differs in two important ways. First, in the
+// absence of time zone information, it interprets a time as in the given location.
+// Second, when given a zone offset or abbreviation, it tries to match it against the
+// location. See [time.ParseInLocation] for more details.
+func ParseJavaInLocation(pattern string, value string, lang string, location *time.Location) (time.Time, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseJavaInLocationWithLocale(pattern, value, location, locale)
+}
+
+// ParseJavaInLocationWithLocale is like ParseJavaInLocation, but instead of receiving a
+// BCP 47 language tag argument, it receives a built [lunes.Locale], avoiding looking up
+// existing data in each operation and allowing extensibility.
+func ParseJavaInLocationWithLocale(pattern string, value string, location *time.Location, locale Locale) (time.Time, error) {
+	var errs []error
+	for _, format := range strings.Split(pattern, "||") {
+		t, err := parseJavaFormat(format, value, location, locale)
+		if err == nil {
+			return t, nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return time.Time{}, errors.Join(errs...)
+}
+
+// parseJavaFormat parses the value using the Elasticsearch built-in format, or the Java
+// pattern alternatives, returning the error of the most complete alternative if none
+// of them matches.
+func parseJavaFormat(format string, value string, location *time.Location, locale Locale) (time.Time, error) {
+	name := strings.TrimPrefix(format, "strict_")
+	switch name {
+	case "epoch_millis":
+		return parseEpoch(format, value, time.Millisecond, location)
+	case "epoch_second":
+		return parseEpoch(format, value, time.Second, location)
+	}
+
+	pattern, ok := elasticsearchFormats[name]
+	if !ok {
+		pattern = format
+	}
+
+	alternatives, err := expandJavaOptionals(pattern)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var firstErr error
+	for _, alternative := range alternatives {
+		layout, err := LDMLToLayout(alternative)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		t, err := ParseInLocationWithLocale(layout, value, location, locale)
+		if err == nil {
+			if hasTwoDigitsYear(alternative) && t.Year() < 2000 {
+				// Java parses the two digits years in the 2000-2099 range
+				t = t.AddDate(100, 0, 0)
+			}
+			return t, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return time.Time{}, firstErr
+}
+
+// expandJavaOptionals expands the Java pattern optional sections into the patterns
+// alternatives, sorted from the most complete to the least.
+func expandJavaOptionals(pattern string) ([]string, error) {
+	stack := [][]string{{""}}
+	appendText := func(text string) {
+		top := stack[len(stack)-1]
+		for i := range top {
+			top[i] += text
+		}
+	}
+
+	for i := 0; i < len(pattern); {
+		switch pattern[i] {
+		case '\'':
+			end := i + 1
+			for end < len(pattern) {
+				if pattern[end] == '\'' {
+					if end+1 < len(pattern) && pattern[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+
+			end = min(end+1, len(pattern))
+			appendText(pattern[i:end])
+			i = end
+		case '[':
+			stack = append(stack, []string{""})
+			i++
+		case ']':
+			if len(stack) == 1 {
+				return nil, newUnsupportedPatternElemError("]", pattern)
+			}
+
+			section := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			top := stack[len(stack)-1]
+			alternatives := make([]string, 0, len(top)*(len(section)+1))
+			for _, prefix := range top {
+				for _, s := range section {
+					alternatives = append(alternatives, prefix+s)
+				}
+			}
+			alternatives = append(alternatives, top...)
+			if len(alternatives) > maxJavaPatternAlternatives {
+				return nil, newUnsupportedPatternElemError("[", pattern)
+			}
+
+			stack[len(stack)-1] = alternatives
+			i++
+		case '{', '}', '#':
+			// reserved for future use by Java
+			return nil, newUnsupportedPatternElemError(pattern[i:i+1], pattern)
+		default:
+			appendText(pattern[i : i+1])
+			i++
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, newUnsupportedPatternElemError("[", pattern)
+	}
+
+	return stack[0], nil
+}
+
+// hasTwoDigitsYear reports whether the Java pattern has a two digits year field.
+func hasTwoDigitsYear(pattern string) bool {
+	for _, token := range ldmlTokens(pattern) {
+		if token.field && (token.raw == "yy" || token.raw == "uu") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEpoch parses the Elasticsearch epoch formats values, which are a number of units
+// since the Unix epoch, optionally followed by a fraction (e.g. "1729000000.5").
+func parseEpoch(format string, value string, unit time.Duration, location *time.Location) (time.Time, error) {
+	integer, fraction, hasFraction := strings.Cut(value, ".")
+	n, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil || (hasFraction && fraction == "") {
+		return time.Time{}, newLayoutMismatchError(format, value)
+	}
+
+	var t time.Time
+	if unit == time.Second {
+		t = time.Unix(n, 0)
+	} else {
+		t = time.UnixMilli(n)
+	}
+
+	if fraction != "" {
+		f, err := strconv.ParseUint(fraction, 10, 64)
+		if err != nil || len(fraction) > 18 {
+			return time.Time{}, newLayoutMismatchError(format, value)
+		}
+
+		for range fraction {
+			unit /= 10
+		}
+
+		if strings.HasPrefix(integer, "-") {
+			t = t.Add(-time.Duration(f) * unit)
+		} else {
+			t = t.Add(time.Duration(f) * unit)
+		}
+	}
+
+	return t.In(location), nil
+}
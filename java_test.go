@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseJava(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		value   string
+		lang    string
+		want    time.Time
+	}{
+		{name: "Apache", pattern: "dd/MMM/yyyy:HH:mm:ss Z", value: "15/Oct/2024:09:41:30 +0200", lang: LocaleEn, want: time.Date(2024, time.October, 15, 7, 41, 30, 0, time.UTC)},
+		{name: "Localized", pattern: "EEEE, d 'de' MMMM 'de' uuuu", value: "martes, 15 de octubre de 2024", lang: LocaleEs, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "OptionalAbsent", pattern: "yyyy-MM-dd['T'HH:mm[:ss]]", value: "2024-10-15", lang: LocaleEn, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "OptionalPresent", pattern: "yyyy-MM-dd['T'HH:mm[:ss]]", value: "2024-10-15T09:41", lang: LocaleEn, want: time.Date(2024, time.October, 15, 9, 41, 0, 0, time.UTC)},
+		{name: "OptionalNested", pattern: "yyyy-MM-dd['T'HH:mm[:ss]]", value: "2024-10-15T09:41:30", lang: LocaleEn, want: time.Date(2024, time.October, 15, 9, 41, 30, 0, time.UTC)},
+		{name: "QuotedBrackets", pattern: "'['yyyy']'", value: "[2024]", lang: LocaleEn, want: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "TwoDigitsYear", pattern: "dd.MM.yy", value: "15.10.70", lang: LocaleDe, want: time.Date(2070, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "TwoDigitsProlepticYear", pattern: "dd.MM.uu", value: "15.10.24", lang: LocaleDe, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "NamedFormat", pattern: "strict_date_optional_time", value: "2024-10-15T09:41:30.123Z", lang: LocaleEn, want: time.Date(2024, time.October, 15, 9, 41, 30, 123000000, time.UTC)},
+		{name: "NamedFormatYearMonth", pattern: "date_optional_time", value: "2024-10", lang: LocaleEn, want: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "NamedBasicFormat", pattern: "basic_date_time", value: "20241015T094130.5+0200", lang: LocaleEn, want: time.Date(2024, time.October, 15, 7, 41, 30, 500000000, time.UTC)},
+		{name: "EpochMillis", pattern: "epoch_millis", value: "1729000000123", lang: LocaleEn, want: time.UnixMilli(1729000000123)},
+		{name: "EpochSecond", pattern: "epoch_second", value: "1729000000.5", lang: LocaleEn, want: time.Unix(1729000000, 500000000)},
+		{name: "NegativeEpochSecond", pattern: "epoch_second", value: "-1.25", lang: LocaleEn, want: time.Unix(-2, 750000000)},
+		{name: "Alternatives", pattern: "yyyy/MM/dd||epoch_millis", value: "1729000000123", lang: LocaleEn, want: time.UnixMilli(1729000000123)},
+		{name: "FirstAlternative", pattern: "dd MMMM yyyy||epoch_millis", value: "15 października 2024", lang: LocalePl, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJava(tt.pattern, tt.value, tt.lang)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("InLocation", func(t *testing.T) {
+		got, err := ParseJavaInLocation("yyyy-MM-dd HH:mm", "2024-10-15 09:41", LocaleEn, defaultLocation)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		want := time.Date(2024, time.October, 15, 9, 41, 0, 0, defaultLocation)
+		if !got.Equal(want) || got.Location() != defaultLocation {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+
+	unsupported := []struct {
+		pattern string
+		elem    string
+		want    string
+	}{
+		{pattern: "weekyear", elem: "YYYY", want: "YYYY"},
+		{pattern: "yyyy[-MM", elem: "[", want: "yyyy[-MM"},
+		{pattern: "yyyy]", elem: "]", want: "yyyy]"},
+		{pattern: "yyyy#", elem: "#", want: "yyyy#"},
+		{pattern: "yyyy-MM-dd HH:mm:ss.nnn", elem: "nnn", want: "yyyy-MM-dd HH:mm:ss.nnn"},
+	}
+
+	for _, tt := range unsupported {
+		t.Run("Unsupported "+tt.pattern, func(t *testing.T) {
+			_, err := ParseJava(tt.pattern, "2024", LocaleEn)
+			expected := newUnsupportedPatternElemError(tt.elem, tt.want)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+
+	t.Run("Mismatch", func(t *testing.T) {
+		_, err := ParseJava("yyyy-MM-dd||epoch_millis", "15/10/2024", LocaleEn)
+		expected := newLayoutMismatchError("epoch_millis", "15/10/2024")
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+
+		var parseErr *time.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("expected a time.ParseError error, got: '%v'", err)
+		}
+	})
+}
+
+func TestExpandJavaOptionals(t *testing.T) {
+	got, err := expandJavaOptionals("a[b][c'[x]'[d]]")
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	want := []string{"abc'[x]'d", "abc'[x]'", "ac'[x]'d", "ac'[x]'", "ab", "a"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import "strings"
+
+// FormatStyle is the length of the CLDR date and time formats used by [LayoutFor].
+type FormatStyle int
+
+const (
+	// NoStyle omits the date or the time from the layout.
+	NoStyle FormatStyle = iota
+	// Full is the most detailed format (e.g. "Tuesday, April 12, 1952").
+	Full
+	// Long is a detailed format (e.g. "April 12, 1952", or "3:30:32 PM PST").
+	Long
+	// Medium is an abbreviated format (e.g. "Apr 12, 1952", or "3:30:32 PM").
+	Medium
+	// Short is the most abbreviated format (e.g. "4/12/52", or "3:30 PM").
+	Short
+)
+
+// LayoutFor returns the default layout of the language for the given date and time styles,
+// built from the CLDR date, time, and date time formats. The time formats use the hour cycle
+// preferred by the language region (e.g. "3:04 PM" for "en-US", and "15:04" for "en-GB").
+// Either the date or the time can be omitted using the [NoStyle] style. The returned layout
+// can be used by the parsing functions with the same language, for example:
+//
+//	layout, err := lunes.LayoutFor(lunes.LocaleDe, lunes.Long, lunes.NoStyle) // 2. January 2006
+//	t, err := lunes.Parse(layout, "3. Oktober 2024", lunes.LocaleDe)
+//
+// Layouts including time zones names use the MST layout element, which requires the
+// [Options.TimeZoneNames] option to parse localized names.
+//
+// The language argument must be a well-formed BCP 47 language tag, e.g ("en", "en-US") and
+// a known locale. If no data is found for the language, it returns ErrUnsupportedLocale.
+// If the language has no formats for the given styles, it results in an ErrUnsupportedLayoutElem
+// error, and if a format cannot be represented by a layout, in an ErrUnsupportedPatternElem error.
+func LayoutFor(lang string, dateStyle FormatStyle, timeStyle FormatStyle) (string, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return "", err
+	}
+
+	return LayoutForWithLocale(locale, dateStyle, timeStyle)
+}
+
+// LayoutForWithLocale is like LayoutFor, but instead of receiving a BCP 47 language tag
+// argument, it receives a built [lunes.Locale], which must implement the [DateTimeFormatLocale]
+// interface.
+func LayoutForWithLocale(locale Locale, dateStyle FormatStyle, timeStyle FormatStyle) (string, error) {
+	formats, ok := locale.(DateTimeFormatLocale)
+	if !ok {
+		return "", newUnsupportedLayoutElemError("date time format", locale)
+	}
+
+	var datePattern, timePattern string
+	if dateStyle != NoStyle {
+		if datePattern, ok = lookupFormat(formats.DateFormats(), dateStyle); !ok {
+			return "", newUnsupportedLayoutElemError("date format", locale)
+		}
+	}
+
+	if timeStyle != NoStyle {
+		if timePattern, ok = lookupFormat(formats.TimeFormats(), timeStyle); !ok {
+			return "", newUnsupportedLayoutElemError("time format", locale)
+		}
+		timePattern = applyHourCycle(timePattern, formats.HourCycle())
+	}
+
+	pattern := datePattern + timePattern
+	if datePattern != "" && timePattern != "" {
+		// the date style selects the date time format, as the CLDR specifies
+		dateTimePattern, ok := lookupFormat(formats.DateTimeFormats(), dateStyle)
+		if !ok {
+			// the CLDR root locale date time format
+			dateTimePattern = "{1} {0}"
+		}
+		pattern = strings.NewReplacer("{0}", timePattern, "{1}", datePattern).Replace(dateTimePattern)
+	}
+
+	return LDMLToLayout(pattern)
+}
+
+func lookupFormat(formats []string, style FormatStyle) (string, bool) {
+	index := int(style) - 1
+	if index < 0 || index >= len(formats) || formats[index] == "" {
+		return "", false
+	}
+	return formats[index], true
+}
+
+// applyHourCycle rewrites the time pattern hours using the preferred hour cycle, removing
+// or adding the day period, which is added after the time fields. The 0-11 (K) and 1-24
+// (k) hour cycles have no layout elements, so the 1-12 (h) and 0-23 (H) ones are used.
+func applyHourCycle(pattern string, hourCycle string) string {
+	var hour byte
+	switch hourCycle {
+	case "h", "K":
+		hour = 'h'
+	case "H", "k":
+		hour = 'H'
+	default:
+		return pattern
+	}
+
+	tokens := ldmlTokens(pattern)
+	lastTimeField := -1
+	var hasHour, twelveHours bool
+	for i, token := range tokens {
+		if !token.field {
+			continue
+		}
+
+		switch token.raw[0] {
+		case 'h', 'K':
+			hasHour, twelveHours = true, true
+		case 'H', 'k':
+			hasHour = true
+		}
+
+		if strings.IndexByte("hHkKmsS", token.raw[0]) >= 0 {
+			lastTimeField = i
+		}
+	}
+
+	if !hasHour || twelveHours == (hour == 'h') {
+		return pattern
+	}
+
+	raws := make([]string, 0, len(tokens)+1)
+	var skipSpace bool
+	for i, token := range tokens {
+		switch {
+		case token.field && strings.IndexByte("hHkK", token.raw[0]) >= 0:
+			// the CLDR formats usually pad the 0-23 hours only
+			if hour == 'h' {
+				raws = append(raws, "h")
+			} else {
+				raws = append(raws, "HH")
+			}
+		case token.field && strings.IndexByte("abB", token.raw[0]) >= 0:
+			// the day period is removed along with one of its surrounding spaces
+			if len(raws) > 0 && isSpaceLiteral(raws[len(raws)-1]) {
+				raws = raws[:len(raws)-1]
+			} else {
+				skipSpace = true
+			}
+			continue
+		case skipSpace && isSpaceLiteral(token.raw):
+		default:
+			raws = append(raws, token.raw)
+		}
+
+		skipSpace = false
+		if hour == 'h' && i == lastTimeField {
+			raws = append(raws, " a")
+		}
+	}
+
+	return strings.Join(raws, "")
+}
+
+func isSpaceLiteral(raw string) bool {
+	return raw != "" && strings.TrimSpace(raw) == ""
+}
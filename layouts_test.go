@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var plFormatsTestFields = map[int][]string{
+	dateFormatsField:     {"EEEE, d MMMM y", "d MMMM y", "d MMM y", "d.MM.y"},
+	timeFormatsField:     {"HH:mm:ss zzzz", "HH:mm:ss z", "HH:mm:ss", "HH:mm"},
+	dateTimeFormatsField: {"{1} {0}", "{1} {0}", "{1}, {0}", "{1}, {0}"},
+	hourCycleField:       {"H"},
+}
+
+func TestLayoutFor(t *testing.T) {
+	pl := newTestLocale(LocalePl, plFormatsTestFields)
+
+	enFormats := [][]string{
+		{"EEEE, MMMM d, y", "MMMM d, y", "MMM d, y", "M/d/yy"},
+		{"h:mm:ss a zzzz", "h:mm:ss a z", "h:mm:ss a", "h:mm a"},
+		{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+	}
+	enUS := newTestLocale(LocaleEnUS, map[int][]string{dateFormatsField: enFormats[0], timeFormatsField: enFormats[1], dateTimeFormatsField: enFormats[2], hourCycleField: {"h"}})
+	enGB := newTestLocale(LocaleEnGB, map[int][]string{dateFormatsField: enFormats[0], timeFormatsField: enFormats[1], dateTimeFormatsField: enFormats[2], hourCycleField: {"H"}})
+	plUS := newTestLocale(LocalePl, map[int][]string{dateFormatsField: pl.DateFormats(), timeFormatsField: pl.TimeFormats(), dateTimeFormatsField: nil, hourCycleField: {"h"}})
+
+	tests := []struct {
+		name      string
+		locale    Locale
+		dateStyle FormatStyle
+		timeStyle FormatStyle
+		want      string
+	}{
+		{name: "FullDate", locale: pl, dateStyle: Full, want: "Monday, 2 January 2006"},
+		{name: "ShortDate", locale: pl, dateStyle: Short, want: "2.01.2006"},
+		{name: "MediumTime", locale: pl, timeStyle: Medium, want: "15:04:05"},
+		{name: "LongDateTime", locale: pl, dateStyle: Long, timeStyle: Long, want: "2 January 2006 15:04:05 MST"},
+		{name: "MediumDateShortTime", locale: pl, dateStyle: Medium, timeStyle: Short, want: "2 Jan 2006, 15:04"},
+		{name: "TwelveHours", locale: enUS, dateStyle: Short, timeStyle: Short, want: "1/2/06, 3:04 PM"},
+		{name: "RegionTwentyFourHours", locale: enGB, timeStyle: Full, want: "15:04:05 MST"},
+		{name: "RegionTwelveHours", locale: plUS, timeStyle: Long, want: "3:04:05 PM MST"},
+		{name: "DefaultDateTimeFormat", locale: plUS, dateStyle: Short, timeStyle: Short, want: "2.01.2006 3:04 PM"},
+		{name: "NoStyles", locale: pl},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LayoutForWithLocale(tt.locale, tt.dateStyle, tt.timeStyle)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Parse", func(t *testing.T) {
+		layout, err := LayoutForWithLocale(pl, Full, Short)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		got, err := ParseInLocationWithLocale(layout, "wtorek, 15 października 2024 9:41", defaultLocation, pl)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		want := time.Date(2024, time.October, 15, 9, 41, 0, 0, defaultLocation)
+		if !got.Equal(want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("UnsupportedDateTimeFormats", func(t *testing.T) {
+		_, err := LayoutForWithLocale(&customLocale{pl}, Full, NoStyle)
+		expected := newUnsupportedLayoutElemError("date time format", pl)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("MissingFormat", func(t *testing.T) {
+		_, err := LayoutForWithLocale(newTestLocale(LocalePl, map[int][]string{timeFormatsField: nil}), NoStyle, Short)
+		expected := newUnsupportedLayoutElemError("time format", pl)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("UnsupportedPattern", func(t *testing.T) {
+		zh := newTestLocale(LocaleZhHant, map[int][]string{timeFormatsField: {"", "", "", "Bh:mm"}, hourCycleField: {"h"}})
+		_, err := LayoutForWithLocale(zh, NoStyle, Short)
+		expected := newUnsupportedPatternElemError("B", "Bh:mm")
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("UnsupportedLocale", func(t *testing.T) {
+		_, err := LayoutFor("ann", Full, Full)
+		var e *ErrUnsupportedLocale
+		if !errors.As(err, &e) {
+			t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"ann"}, err)
+		}
+	})
+}
+
+func TestLayoutForLocales(t *testing.T) {
+	tests := []struct {
+		lang      string
+		dateStyle FormatStyle
+		timeStyle FormatStyle
+		want      string
+	}{
+		{lang: LocaleDe, dateStyle: Long, timeStyle: Short, want: "2. January 2006 um 15:04"},
+		{lang: LocaleDe, dateStyle: Short, timeStyle: NoStyle, want: "02.01.06"},
+		{lang: LocalePl, dateStyle: Medium, timeStyle: Short, want: "2 Jan 2006, 15:04"},
+		{lang: LocaleFr, dateStyle: Full, timeStyle: NoStyle, want: "Monday 2 January 2006"},
+		{lang: LocaleEnGB, dateStyle: NoStyle, timeStyle: Medium, want: "15:04:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d %d", tt.lang, tt.dateStyle, tt.timeStyle), func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, dateFormatsField, timeFormatsField, dateTimeFormatsField, hourCycleField)
+
+			got, err := LayoutFor(tt.lang, tt.dateStyle, tt.timeStyle)
+			if err != nil || got != tt.want {
+				t.Errorf("expected: '%s', got: '%s' ('%v')", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestApplyHourCycle(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		hourCycle string
+		want      string
+	}{
+		{pattern: "HH:mm", hourCycle: "H", want: "HH:mm"},
+		{pattern: "HH:mm", hourCycle: "", want: "HH:mm"},
+		{pattern: "h:mm a", hourCycle: "K", want: "h:mm a"},
+		{pattern: "h:mm a", hourCycle: "H", want: "HH:mm"},
+		{pattern: "h:mm:ss a zzzz", hourCycle: "k", want: "HH:mm:ss zzzz"},
+		{pattern: "a h:mm", hourCycle: "H", want: "HH:mm"},
+		{pattern: "ah:mm", hourCycle: "H", want: "HH:mm"},
+		{pattern: "HH:mm:ss z", hourCycle: "h", want: "h:mm:ss a z"},
+		{pattern: "HH 'h' mm", hourCycle: "h", want: "h 'h' mm a"},
+		{pattern: "d MMM y", hourCycle: "h", want: "d MMM y"},
+	}
+
+	for _, tt := range tests {
+		got := applyHourCycle(tt.pattern, tt.hourCycle)
+		if got != tt.want {
+			t.Errorf("expected: '%s' for '%s' (%s), got: '%s'", tt.want, tt.pattern, tt.hourCycle, got)
+		}
+	}
+}
@@ -0,0 +1,517 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ldmlToken is a LDML pattern field, a run of the same pattern letter (e.g. "MMM"), or a
+// literal text, which can be quoted (e.g. "'de'").
+type ldmlToken struct {
+	raw     string
+	field   bool
+	literal string
+}
+
+// LayoutToLDML converts the layout (e.g. "Mon, 02 Jan 2006 15:04:05 MST") to the equivalent
+// Unicode LDML date format pattern (e.g. "EEE, dd MMM yyyy HH:mm:ss z"), quoting the literals.
+// The lunes era layout elements (AD and Anno Domini) are converted to the era pattern fields.
+// Layout elements with no pattern equivalent, such as the space padded days (_2 and __2),
+// the lower case day period (pm), and the trimmed fractional seconds (e.g. ".999"), result
+// in an ErrUnsupportedLayoutConversion error.
+func LayoutToLDML(layout string) (string, error) {
+	var sb strings.Builder
+	var literalOffset int
+	var lastField byte
+	for i := 0; i < len(layout); {
+		elem, field := nextLayoutElem(layout, i)
+		if elem == "" {
+			i++
+			continue
+		}
+
+		if field == "" {
+			return "", newUnsupportedLayoutConversionError(elem, layout)
+		}
+
+		literal := layout[literalOffset:i]
+		// adjacent fields of the same letter would be read as a single field
+		if literal == "" && field[0] == lastField {
+			return "", newUnsupportedLayoutConversionError(elem, layout)
+		}
+
+		sb.WriteString(quoteLDMLLiteral(literal))
+		sb.WriteString(field)
+		lastField = field[len(field)-1]
+		i += len(elem)
+		literalOffset = i
+	}
+
+	sb.WriteString(quoteLDMLLiteral(layout[literalOffset:]))
+	return sb.String(), nil
+}
+
+// layoutFields maps the layout elements to the equivalent LDML pattern fields. Elements
+// with no equivalent fields are mapped to empty strings.
+var layoutFields = map[string]string{
+	"January": "MMMM", "Jan": "MMM", "1": "M", "01": "MM",
+	"Monday": "EEEE", "Mon": "EEE",
+	"2": "d", "02": "dd", "_2": "", "__2": "", "002": "DDD",
+	"2006": "yyyy", "06": "yy",
+	"15": "HH", "3": "h", "03": "hh", "4": "m", "04": "mm", "5": "s", "05": "ss",
+	"PM": "a", "pm": "",
+	"MST": "z",
+	"-07": "x", "-0700": "xx", "-07:00": "xxx", "-070000": "xxxx", "-07:00:00": "xxxxx",
+	"Z07": "X", "Z0700": "XX", "Z07:00": "XXX", "Z070000": "XXXX", "Z07:00:00": "XXXXX",
+	"AD": "G", "Anno Domini": "GGGG",
+}
+
+// layoutZoneElems holds the numeric time zones layout elements, longest first.
+var layoutZoneElems = []string{"-07:00:00", "-070000", "-07:00", "-0700", "-07", "Z07:00:00", "Z070000", "Z07:00", "Z0700", "Z07"}
+
+// nextLayoutElem returns the layout element starting at the offset, as the time package
+// reads it, and its pattern field. If there is no layout element, it returns empty strings.
+func nextLayoutElem(layout string, offset int) (string, string) {
+	value := layout[offset:]
+	elem := ""
+	switch value[0] {
+	case 'J': // January, Jan
+		if strings.HasPrefix(value, "January") {
+			elem = "January"
+		} else if strings.HasPrefix(value, "Jan") && !startsWithLowerCase(value[3:]) {
+			elem = "Jan"
+		}
+	case 'M': // Monday, Mon, MST
+		if strings.HasPrefix(value, "Monday") {
+			elem = "Monday"
+		} else if strings.HasPrefix(value, "Mon") && !startsWithLowerCase(value[3:]) {
+			elem = "Mon"
+		} else if strings.HasPrefix(value, "MST") {
+			elem = "MST"
+		}
+	case 'A': // Anno Domini, AD
+		elem = eraLayoutElemAt(layout, offset)
+	case '0': // 01, 02, 03, 04, 05, 06, 002
+		if len(value) >= 2 && value[1] >= '1' && value[1] <= '6' {
+			elem = value[:2]
+		} else if strings.HasPrefix(value, "002") {
+			elem = "002"
+		}
+	case '1': // 15, 1
+		elem = "1"
+		if strings.HasPrefix(value, "15") {
+			elem = "15"
+		}
+	case '2': // 2006, 2
+		elem = "2"
+		if strings.HasPrefix(value, "2006") {
+			elem = "2006"
+		}
+	case '_': // _2, __2, where _2006 is a literal underscore followed by a year
+		if strings.HasPrefix(value, "_2") && !strings.HasPrefix(value, "_2006") {
+			elem = "_2"
+		} else if strings.HasPrefix(value, "__2") {
+			elem = "__2"
+		}
+	case '3', '4', '5':
+		elem = value[:1]
+	case 'P', 'p': // PM, pm
+		if strings.HasPrefix(value, "PM") || strings.HasPrefix(value, "pm") {
+			elem = value[:2]
+		}
+	case '-', 'Z': // -07:00:00, -070000, -07:00, -0700, -07, and the Z variants
+		for _, zoneElem := range layoutZoneElems {
+			if zoneElem[0] == value[0] && strings.HasPrefix(value, zoneElem) {
+				elem = zoneElem
+				break
+			}
+		}
+	case '.', ',': // .000, .999, or the comma variants
+		if len(value) >= 2 && (value[1] == '0' || value[1] == '9') {
+			j := 1
+			for j < len(value) && value[j] == value[1] {
+				j++
+			}
+
+			// digits following the fractional seconds make them literals
+			if j < len(value) && value[j] >= '0' && value[j] <= '9' {
+				return "", ""
+			}
+
+			if value[1] == '9' {
+				return value[:j], ""
+			}
+
+			return value[:j], value[:1] + strings.Repeat("S", j-1)
+		}
+	}
+
+	if elem == "" {
+		return "", ""
+	}
+
+	return elem, layoutFields[elem]
+}
+
+// quoteLDMLLiteral quotes the words of the layout literal, so that their letters are not
+// interpreted as pattern fields.
+func quoteLDMLLiteral(literal string) string {
+	var sb strings.Builder
+	for i := 0; i < len(literal); {
+		if !isPatternLetter(literal[i]) && literal[i] != '\'' {
+			sb.WriteByte(literal[i])
+			i++
+			continue
+		}
+
+		start := i
+		letters := false
+		for i < len(literal) && (isPatternLetter(literal[i]) || literal[i] == '\'') {
+			letters = letters || literal[i] != '\''
+			i++
+		}
+
+		word := strings.ReplaceAll(literal[start:i], "'", "''")
+		if letters {
+			word = "'" + word + "'"
+		}
+		sb.WriteString(word)
+	}
+
+	return sb.String()
+}
+
+// ParseLDML parses a formatted string in foreign language using the Unicode LDML date format
+// pattern (e.g. "d 'de' MMMM 'de' y, HH:mm"), which is converted to the equivalent layout by
+// [LDMLToLayout], and returns the [time.Time] value it represents. See [Parse] for more details.
+//
+// To execute several parses for the same locale, use [ParseLDMLWithLocale] as it performs better.
+func ParseLDML(pattern string, value string, lang string) (time.Time, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseLDMLWithLocale(pattern, value, locale)
+}
+
+// ParseLDMLWithLocale is like ParseLDML, but instead of receiving a BCP 47 language tag
+// argument, it receives a built [lunes.Locale], avoiding looking up existing data in each
+// operation and allowing extensibility.
+func ParseLDMLWithLocale(pattern string, value string, locale Locale) (time.Time, error) {
+	layout, err := LDMLToLayout(pattern)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseWithLocale(layout, value, locale)
+}
+
+// ldmlTokens splits the LDML pattern into fields and literals.
+func ldmlTokens(pattern string) []ldmlToken {
+	var tokens []ldmlToken
+	for i := 0; i < len(pattern); {
+		start := i
+		switch c := pattern[i]; {
+		case isPatternLetter(c):
+			for i < len(pattern) && pattern[i] == c {
+				i++
+			}
+			tokens = append(tokens, ldmlToken{raw: pattern[start:i], field: true})
+		case c == '\'':
+			var sb strings.Builder
+			i++
+			if i < len(pattern) && pattern[i] == '\'' {
+				// two single quotes represent a literal single quote
+				sb.WriteByte('\'')
+				i++
+			} else {
+				for i < len(pattern) {
+					if pattern[i] == '\'' {
+						if i+1 < len(pattern) && pattern[i+1] == '\'' {
+							sb.WriteByte('\'')
+							i += 2
+							continue
+						}
+						i++
+						break
+					}
+					sb.WriteByte(pattern[i])
+					i++
+				}
+			}
+			tokens = append(tokens, ldmlToken{raw: pattern[start:i], literal: sb.String()})
+		default:
+			for i < len(pattern) && pattern[i] != '\'' && !isPatternLetter(pattern[i]) {
+				i++
+			}
+			tokens = append(tokens, ldmlToken{raw: pattern[start:i], literal: pattern[start:i]})
+		}
+	}
+
+	return tokens
+}
+
+// LDMLToLayout converts the Unicode LDML (CLDR and ICU) date format pattern (e.g. "d 'de'
+// MMMM 'de' y, HH:mm") to the equivalent [time.Layout] (e.g. "2 de January de 2006, 15:04").
+// Pattern fields that have no layout equivalent, such as quarters (Q), week numbers (w), or
+// flexible day periods (B), and literals that would be interpreted as layout elements (e.g.
+// "'Mon'"), result in an ErrUnsupportedPatternElem error.
+//
+// The era fields (G and GGGG) are converted to the lunes era layout elements (AD and Anno
+// Domini), which are only read as eras as whole words, so eras adjacent to letters (e.g.
+// "MMMG") are not supported either.
+//
+// Time zones names (z, zzzz, v, vvvv, O, OOOO, and ZZZZ) are converted to the MST layout
+// element, which requires the [Options.TimeZoneNames] option to parse localized names.
+func LDMLToLayout(pattern string) (string, error) {
+	b := make([]byte, 0, len(pattern)+8)
+	// the era field just converted, which must not be followed by a letter
+	var era string
+	for _, token := range ldmlTokens(pattern) {
+		elem := token.literal
+		if !token.field {
+			if containsLayoutElem(string(b), token.literal) {
+				return "", newUnsupportedPatternElemError(token.raw, pattern)
+			}
+		} else {
+			var ok bool
+			if elem, ok = ldmlLayoutElem(token.raw, b); !ok {
+				return "", newUnsupportedPatternElemError(token.raw, pattern)
+			}
+		}
+
+		if era != "" {
+			if r, _ := utf8.DecodeRuneInString(elem); unicode.IsLetter(r) {
+				return "", newUnsupportedPatternElemError(era, pattern)
+			}
+		}
+
+		era = ""
+		if token.field && token.raw[0] == 'G' {
+			era = token.raw
+		}
+
+		b = append(b, elem...)
+	}
+
+	return string(b), nil
+}
+
+// ldmlLayoutElem returns the layout element equivalent to the LDML pattern field. The
+// fractional seconds are only supported after a period or comma, which the layout
+// element includes.
+func ldmlLayoutElem(field string, layout []byte) (string, bool) {
+	count := len(field)
+	switch field[0] {
+	case 'G': // era
+		// the era must not follow a letter
+		if r, _ := utf8.DecodeLastRune(layout); unicode.IsLetter(r) {
+			return "", false
+		}
+		switch {
+		case count <= 3:
+			return "AD", true
+		case count == 4:
+			return "Anno Domini", true
+		}
+	case 'y', 'u': // year, extended year
+		if count == 2 {
+			return "06", true
+		}
+		return "2006", true
+	case 'M', 'L': // month, stand-alone month
+		switch count {
+		case 1:
+			return "1", true
+		case 2:
+			return "01", true
+		case 3:
+			return "Jan", true
+		case 4:
+			return "January", true
+		}
+	case 'd': // day of month
+		switch count {
+		case 1:
+			return "2", true
+		case 2:
+			return "02", true
+		}
+	case 'D': // day of year
+		if count == 3 {
+			return "002", true
+		}
+	case 'E', 'c', 'e': // day of week, stand-alone and local day of week
+		switch {
+		case count <= 3 && (field[0] == 'E' || count == 3):
+			return "Mon", true
+		case count == 4:
+			return "Monday", true
+		}
+	case 'a': // AM or PM
+		return "PM", true
+	case 'h': // hour 1-12
+		switch count {
+		case 1:
+			return "3", true
+		case 2:
+			return "03", true
+		}
+	case 'H': // hour 0-23
+		if count <= 2 {
+			return "15", true
+		}
+	case 'm': // minute
+		switch count {
+		case 1:
+			return "4", true
+		case 2:
+			return "04", true
+		}
+	case 's': // second
+		switch count {
+		case 1:
+			return "5", true
+		case 2:
+			return "05", true
+		}
+	case 'S': // fractional second
+		if count <= 9 && len(layout) > 0 && (layout[len(layout)-1] == '.' || layout[len(layout)-1] == ',') {
+			return strings.Repeat("0", count), true
+		}
+	case 'z', 'O', 'v': // specific non-location, localized GMT, and generic non-location zones
+		if count <= 4 && (field[0] == 'z' || count == 1 || count == 4) {
+			return "MST", true
+		}
+	case 'Z': // ISO 8601 basic, localized GMT, and ISO 8601 extended zones
+		switch count {
+		case 1, 2, 3:
+			return "-0700", true
+		case 4:
+			return "MST", true
+		case 5:
+			return "Z07:00", true
+		}
+	case 'X', 'x': // ISO 8601 zones, using Z for UTC (X) or not (x)
+		elems := [...]string{"-07", "-0700", "-07:00", "-070000", "-07:00:00"}
+		if count > len(elems) {
+			return "", false
+		}
+		if field[0] == 'X' {
+			return "Z" + elems[count-1][1:], true
+		}
+		return elems[count-1], true
+	}
+
+	return "", false
+}
+
+// layoutLiteralElems holds the alphabetic layout elements, which must not be part of the
+// pattern literals.
+var layoutLiteralElems = []string{"Jan", "Mon", "MST", "PM", "pm"}
+
+// containsLayoutElem reports whether the pattern literal would be interpreted as a
+// layout element when appended to the layout. The era elements are whole words, so
+// the literal end is considered a word end, as the next field may not start with a
+// letter.
+func containsLayoutElem(layout string, literal string) bool {
+	for i := 0; i < len(literal); i++ {
+		if literal[i] >= '0' && literal[i] <= '9' {
+			return true
+		}
+	}
+
+	for _, elem := range layoutLiteralElems {
+		if strings.Contains(literal, elem) {
+			return true
+		}
+	}
+
+	// the eras starting in the layout and ending in the literal are also found
+	s := layout + literal
+	for i := max(len(layout)-len("Anno Domini")+1, 0); i < len(s); i++ {
+		if elem := eraLayoutElemAt(s, i); elem != "" && i+len(elem) > len(layout) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isPatternLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// ErrUnsupportedPatternElem indicates that a provided LDML pattern element has no
+// equivalent layout element.
+type ErrUnsupportedPatternElem struct {
+	PatternElem string
+	Pattern     string
+}
+
+func (u *ErrUnsupportedPatternElem) Error() string {
+	return fmt.Sprintf(`pattern element "%s" is not supported by the layouts, found in the pattern "%s"`, u.PatternElem, u.Pattern)
+}
+
+func (u *ErrUnsupportedPatternElem) Is(err error) bool {
+	var target *ErrUnsupportedPatternElem
+	if ok := errors.As(err, &target); ok {
+		return u.PatternElem == target.PatternElem && u.Pattern == target.Pattern
+	}
+	return false
+}
+
+func newUnsupportedPatternElemError(elem, pattern string) error {
+	return &ErrUnsupportedPatternElem{
+		PatternElem: elem,
+		Pattern:     pattern,
+	}
+}
+
+// ErrUnsupportedLayoutConversion indicates that a provided layout element has no equivalent
+// LDML pattern field.
+type ErrUnsupportedLayoutConversion struct {
+	LayoutElem string
+	Layout     string
+}
+
+func (u *ErrUnsupportedLayoutConversion) Error() string {
+	return fmt.Sprintf(`layout element "%s" is not supported by the patterns, found in the layout "%s"`, u.LayoutElem, u.Layout)
+}
+
+func (u *ErrUnsupportedLayoutConversion) Is(err error) bool {
+	var target *ErrUnsupportedLayoutConversion
+	if ok := errors.As(err, &target); ok {
+		return u.LayoutElem == target.LayoutElem && u.Layout == target.Layout
+	}
+	return false
+}
+
+func newUnsupportedLayoutConversionError(elem, layout string) error {
+	return &ErrUnsupportedLayoutConversion{
+		LayoutElem: elem,
+		Layout:     layout,
+	}
+}
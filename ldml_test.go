@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLDMLToLayout(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "EEEE, d 'de' MMMM 'de' y", want: "Monday, 2 de January de 2006"},
+		{pattern: "EEE, d MMM yyyy HH:mm:ss Z", want: "Mon, 2 Jan 2006 15:04:05 -0700"},
+		{pattern: "d.MM.yy", want: "2.01.06"},
+		{pattern: "LLLL y", want: "January 2006"},
+		{pattern: "h:mm a zzzz", want: "3:04 PM MST"},
+		{pattern: "HH:mm:ss.SSS", want: "15:04:05.000"},
+		{pattern: "HH:mm:ss,SSSSSS", want: "15:04:05,000000"},
+		{pattern: "yyyy-MM-dd'T'HH:mm:ssXXX", want: "2006-01-02T15:04:05Z07:00"},
+		{pattern: "yyyyMMddHHmmssxx", want: "20060102150405-0700"},
+		{pattern: "DDD/y", want: "002/2006"},
+		{pattern: "h 'o''clock'", want: "3 o'clock"},
+		{pattern: "H''mm", want: "15'04"},
+		{pattern: "y年M月d日", want: "2006年1月2日"},
+		{pattern: "d MMM y G", want: "2 Jan 2006 AD"},
+		{pattern: "GGGG y", want: "Anno Domini 2006"},
+		{pattern: "y-MM G", want: "2006-01 AD"},
+		{pattern: "HH:mm 'ADT'", want: "15:04 ADT"},
+		{pattern: "'MADRID' y", want: "MADRID 2006"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := LDMLToLayout(tt.pattern)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+
+	unsupported := []struct {
+		pattern string
+		elem    string
+	}{
+		{pattern: "GGGGG y", elem: "GGGGG"},
+		{pattern: "MMMG", elem: "G"},
+		{pattern: "G'n' y", elem: "G"},
+		{pattern: "y 'AD'", elem: "'AD'"},
+		{pattern: "'Anno' 'Domini'", elem: "'Domini'"},
+		{pattern: "QQQ y", elem: "QQQ"},
+		{pattern: "w 'week'", elem: "w"},
+		{pattern: "YYYY-ww", elem: "YYYY"},
+		{pattern: "h:mm B", elem: "B"},
+		{pattern: "K:mm a", elem: "K"},
+		{pattern: "EEEEE d", elem: "EEEEE"},
+		{pattern: "ss SSS", elem: "SSS"},
+		{pattern: "d 'Mon' y", elem: "'Mon'"},
+		{pattern: "'1.' MMMM", elem: "'1.'"},
+	}
+
+	for _, tt := range unsupported {
+		t.Run("Unsupported "+tt.pattern, func(t *testing.T) {
+			_, err := LDMLToLayout(tt.pattern)
+			expected := newUnsupportedPatternElemError(tt.elem, tt.pattern)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+}
+
+func TestLayoutToLDML(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{layout: time.RFC1123, want: "EEE, dd MMM yyyy HH:mm:ss z"},
+		{layout: time.RFC3339Nano[:19] + ".000Z07:00", want: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX"},
+		{layout: "Monday, 2 de January de 2006", want: "EEEE, d 'de' MMMM 'de' yyyy"},
+		{layout: "3:04:05 PM -0700", want: "h:mm:ss a xx"},
+		{layout: "02/01/06 15:04,000000", want: "dd/MM/yy HH:mm,SSSSSS"},
+		{layout: "2006 AD Anno Domini", want: "yyyy G GGGG"},
+		{layout: "15:04 ADT", want: "HH:mm 'ADT'"},
+		{layout: "Month 1 o'clock", want: "'Month' M 'o''clock'"},
+		{layout: "2006-002", want: "yyyy-DDD"},
+		{layout: "_2006", want: "_yyyy"},
+		{layout: "2006年1月2日", want: "yyyy年M月d日"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got, err := LayoutToLDML(tt.layout)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected: '%s', got: '%s'", tt.want, got)
+			}
+
+			layout, err := LDMLToLayout(got)
+			if err == nil && layout != tt.layout {
+				t.Errorf("expected round trip layout: '%s', got: '%s'", tt.layout, layout)
+			}
+		})
+	}
+
+	unsupported := []struct {
+		layout string
+		elem   string
+	}{
+		{layout: "Jan _2 15:04", elem: "_2"},
+		{layout: "2006 __2", elem: "__2"},
+		{layout: "3:04pm", elem: "pm"},
+		{layout: "15:04:05.999", elem: ".999"},
+		{layout: "0101", elem: "01"},
+	}
+
+	for _, tt := range unsupported {
+		t.Run("Unsupported "+tt.layout, func(t *testing.T) {
+			_, err := LayoutToLDML(tt.layout)
+			expected := newUnsupportedLayoutConversionError(tt.elem, tt.layout)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+}
+
+func TestParseLDML(t *testing.T) {
+	got, err := ParseLDML("EEEE, d 'de' MMMM 'de' y, HH:mm", "martes, 15 de octubre de 2024, 09:41", LocaleEs)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	want := time.Date(2024, time.October, 15, 9, 41, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+
+	// the era fields are parsed with the locale era names
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+	got, err = ParseLDMLWithLocale("d MMM y G", "15 mar 44 a. C.", esEras)
+	want = time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v ('%v')", want, got, err)
+	}
+
+	_, err = ParseLDML("QQQ y", "T4 2024", LocaleEs)
+	expected := newUnsupportedPatternElemError("QQQ", "QQQ y")
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error: '%v', got: '%v'", expected, err)
+	}
+
+	_, err = ParseLDML("y", "2024", "ann")
+	var e *ErrUnsupportedLocale
+	if !errors.As(err, &e) {
+		t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"ann"}, err)
+	}
+}
@@ -60,9 +60,210 @@ type Locale interface {
 	DayPeriods() []string
 }
 
+// A StandAloneLocale is a Locale that also provides the stand-alone forms of the days and
+// months names. Some languages use different forms when the name is part of a complete date
+// (format context, e.g. the Polish genitive "października") and when it is used on its own
+// (stand-alone context, e.g. the nominative "październik"). Implementing this interface is
+// optional, locales that do not implement it are matched using the format context names only.
+type StandAloneLocale interface {
+	Locale
+
+	// StandAloneLongDayNames returns the stand-alone long day names translations for the
+	// week days. It follows the same rules as [Locale.LongDayNames], and should return an
+	// empty slice if the names do not differ from the format context ones.
+	StandAloneLongDayNames() []string
+
+	// StandAloneShortDayNames returns the stand-alone short day names translations for the
+	// week days. It follows the same rules as [Locale.ShortDayNames], and should return an
+	// empty slice if the names do not differ from the format context ones.
+	StandAloneShortDayNames() []string
+
+	// StandAloneLongMonthNames returns the stand-alone long month names translations. It
+	// follows the same rules as [Locale.LongMonthNames], and should return an empty slice
+	// if the names do not differ from the format context ones.
+	StandAloneLongMonthNames() []string
+
+	// StandAloneShortMonthNames returns the stand-alone short month names translations. It
+	// follows the same rules as [Locale.ShortMonthNames], and should return an empty slice
+	// if the names do not differ from the format context ones.
+	StandAloneShortMonthNames() []string
+}
+
+// A NarrowLocale is a Locale that also provides the compact forms of the days and months
+// names, commonly used by calendar widgets and compact exports. Implementing this interface
+// is optional, and its names are only matched when the [Options.NarrowNames] option is set.
+type NarrowLocale interface {
+	Locale
+
+	// MinDayNames returns the shortest non-narrow day names translations for the week
+	// days (e.g. "Mo", "Tu"). It follows the same rules as [Locale.ShortDayNames].
+	MinDayNames() []string
+
+	// NarrowDayNames returns the narrow day names translations for the week days (e.g.
+	// "M", "T"). It follows the same rules as [Locale.ShortDayNames]. Narrow names are
+	// not required to be unique.
+	NarrowDayNames() []string
+
+	// NarrowMonthNames returns the narrow month names translations (e.g. "J", "F"). It
+	// follows the same rules as [Locale.ShortMonthNames]. Narrow names are not required
+	// to be unique.
+	NarrowMonthNames() []string
+}
+
+// An EraLocale is a Locale that also provides the eras names, used to match the lunes
+// specific era layout elements (AD and Anno Domini). Implementing this interface is optional,
+// but the era elements of layouts are literals for locales that do not implement it.
+type EraLocale interface {
+	Locale
+
+	// LongEraNames returns the long era names translations (e.g. "Before Christ", "Anno Domini").
+	// It must be sorted, starting from BC to AD, and contains both elements, even if one of
+	// them is empty. If this locale does not support this format, it should return an empty
+	// slice.
+	LongEraNames() []string
+
+	// ShortEraNames returns the abbreviated era names translations (e.g. "BC", "AD"). It
+	// follows the same rules as LongEraNames.
+	ShortEraNames() []string
+
+	// NarrowEraNames returns the narrow era names translations (e.g. "B", "A"). It follows
+	// the same rules as LongEraNames.
+	NarrowEraNames() []string
+}
+
+// A FlexibleDayPeriodLocale is a Locale that also provides the CLDR flexible day periods
+// names (e.g. "noon", "in the evening"), and their hours ranges, used to match the PM
+// layout element. Implementing this interface is optional.
+//
+// All slices are sorted following the CLDR day period types order: midnight, am, noon,
+// pm, morning1, morning2, afternoon1, afternoon2, evening1, evening2, night1, night2.
+// Periods not used by the locale must be empty strings.
+type FlexibleDayPeriodLocale interface {
+	Locale
+
+	// LongFlexibleDayPeriods returns the wide flexible day periods translations (e.g.
+	// "in the afternoon"). If this locale does not support this format, it should return
+	// an empty slice.
+	LongFlexibleDayPeriods() []string
+
+	// ShortFlexibleDayPeriods returns the abbreviated flexible day periods translations.
+	// It follows the same rules as LongFlexibleDayPeriods.
+	ShortFlexibleDayPeriods() []string
+
+	// NarrowFlexibleDayPeriods returns the narrow flexible day periods translations. It
+	// follows the same rules as LongFlexibleDayPeriods.
+	NarrowFlexibleDayPeriods() []string
+
+	// DayPeriodRules returns the hours covered by each flexible day period, either as an
+	// exact time (e.g. "12:00"), or as a range including the start and excluding the end
+	// (e.g. "12:00-18:00"). Ranges might cross midnight (e.g. "21:00-06:00").
+	DayPeriodRules() []string
+}
+
+// A NumberingSystemLocale is a Locale that also provides the numbering system used to write
+// its digits. Digits of the numbering system are transliterated to ASCII digits before
+// parsing. Implementing this interface is optional.
+type NumberingSystemLocale interface {
+	Locale
+
+	// NumberingSystem returns the CLDR numbering system identifier (e.g. "latn", "arab",
+	// "deva"). Unknown and algorithmic numbering systems are ignored.
+	NumberingSystem() string
+}
+
+// A TimeZoneLocale is a Locale that also provides the CLDR localized time zones names, used
+// to match the MST layout element when the [Options.TimeZoneNames] option is enabled.
+// Implementing this interface is optional.
+type TimeZoneLocale interface {
+	Locale
+
+	// TimeZoneFormats returns the CLDR time zones formats, sorted as: GMT format (e.g.
+	// "GMT{0}"), hour format (e.g. "+HH:mm;-HH:mm"), GMT zero format (e.g. "GMT"), region
+	// format (e.g. "hora de {0}"), region daylight format, and region standard format. If
+	// this locale does not support this format, it should return an empty slice.
+	TimeZoneFormats() []string
+
+	// LongTimeZoneNames returns the long generic, standard and daylight names of the CLDR
+	// metazones (e.g. "Europe_Central") and time zones (e.g. "Europe/London"), flattened as
+	// zone and name pairs (e.g. "Europe_Central", "hora de verano de Europa central").
+	LongTimeZoneNames() []string
+
+	// ShortTimeZoneNames returns the short zones names (e.g. "CET"). It follows the same
+	// rules as LongTimeZoneNames.
+	ShortTimeZoneNames() []string
+
+	// ExemplarCities returns the time zones exemplar cities, flattened as zone and city
+	// pairs (e.g. "Europe/Warsaw", "Varsovia"). Cities matching the last segment of the time
+	// zone identifier can be omitted.
+	ExemplarCities() []string
+}
+
+// A RelativeTimeLocale is a Locale that also provides the CLDR relative times, used by the
+// relative time parsing functions. Implementing this interface is optional.
+//
+// Relative times are flattened as key and value pairs. Names are keyed by the field and
+// the relative amount (e.g. "day:-1", "yesterday"), and patterns by the field, the future
+// or past direction, and the plural category (e.g. "day:past:other", "{0} days ago").
+// Fields are: year, quarter, month, week, day, hour, minute, second, and the week days
+// sun, mon, tue, wed, thu, fri, and sat.
+type RelativeTimeLocale interface {
+	Locale
+
+	// LongRelativeTimes returns the long relative times translations. If this locale
+	// does not support this format, it should return an empty slice.
+	LongRelativeTimes() []string
+
+	// ShortRelativeTimes returns the short relative times translations (e.g. "in {0} hr.").
+	// It follows the same rules as LongRelativeTimes.
+	ShortRelativeTimes() []string
+
+	// NarrowRelativeTimes returns the narrow relative times translations (e.g. "in {0}h").
+	// It follows the same rules as LongRelativeTimes.
+	NarrowRelativeTimes() []string
+}
+
+// A PluralRulesLocale is a Locale that also provides the CLDR cardinal plural rules, used to
+// select the plural category of the relative times patterns. Implementing this interface
+// is optional.
+type PluralRulesLocale interface {
+	Locale
+
+	// PluralRules returns the plural categories rules, flattened as category and condition
+	// pairs (e.g. "one", "i = 1 and v = 0"). The "other" category, which has no condition,
+	// can be omitted.
+	PluralRules() []string
+}
+
+// A DateTimeFormatLocale is a Locale that also provides the CLDR date and time formats
+// patterns, used by [LayoutForWithLocale] to build the locale default layouts.
+// Implementing this interface is optional.
+type DateTimeFormatLocale interface {
+	Locale
+
+	// DateFormats returns the CLDR date formats patterns, sorted as: full (e.g. "EEEE,
+	// d MMMM y"), long, medium, and short (e.g. "d.MM.y"). If this locale does not
+	// support this format, it should return an empty slice.
+	DateFormats() []string
+
+	// TimeFormats returns the CLDR time formats patterns (e.g. "HH:mm"). It follows the
+	// same rules as DateFormats.
+	TimeFormats() []string
+
+	// DateTimeFormats returns the CLDR patterns combining the time ({0}) and the date
+	// ({1}) formats (e.g. "{1}, {0}"). It follows the same rules as DateFormats.
+	DateTimeFormats() []string
+
+	// HourCycle returns the region preferred hour cycle pattern letter: "h" (1-12),
+	// "H" (0-23), "K" (0-11), or "k" (1-24). If it's empty, the hour cycle of the
+	// time formats is used.
+	HourCycle() string
+}
+
 type genericLocale struct {
 	lang  string
-	table [5][]string
+	table [localeTableSize][]string
+	// tries holds the names tries shared by the default locales of the same language.
+	tries *localeTries
 }
 
 func (g *genericLocale) LongDayNames() []string {
@@ -85,6 +286,122 @@ func (g *genericLocale) DayPeriods() []string {
 	return g.table[dayPeriodsField]
 }
 
+func (g *genericLocale) StandAloneLongDayNames() []string {
+	return g.table[standAloneLongDayNamesField]
+}
+
+func (g *genericLocale) StandAloneShortDayNames() []string {
+	return g.table[standAloneShortDayNamesField]
+}
+
+func (g *genericLocale) StandAloneLongMonthNames() []string {
+	return g.table[standAloneLongMonthNamesField]
+}
+
+func (g *genericLocale) StandAloneShortMonthNames() []string {
+	return g.table[standAloneShortMonthNamesField]
+}
+
+func (g *genericLocale) MinDayNames() []string {
+	return g.table[minDayNamesField]
+}
+
+func (g *genericLocale) NarrowDayNames() []string {
+	return g.table[narrowDayNamesField]
+}
+
+func (g *genericLocale) NarrowMonthNames() []string {
+	return g.table[narrowMonthNamesField]
+}
+
+func (g *genericLocale) LongEraNames() []string {
+	return g.table[longEraNamesField]
+}
+
+func (g *genericLocale) ShortEraNames() []string {
+	return g.table[shortEraNamesField]
+}
+
+func (g *genericLocale) NarrowEraNames() []string {
+	return g.table[narrowEraNamesField]
+}
+
+func (g *genericLocale) LongFlexibleDayPeriods() []string {
+	return g.table[longFlexibleDayPeriodsField]
+}
+
+func (g *genericLocale) ShortFlexibleDayPeriods() []string {
+	return g.table[shortFlexibleDayPeriodsField]
+}
+
+func (g *genericLocale) NarrowFlexibleDayPeriods() []string {
+	return g.table[narrowFlexibleDayPeriodsField]
+}
+
+func (g *genericLocale) DayPeriodRules() []string {
+	return g.table[dayPeriodRulesField]
+}
+
+// NumberingSystem returns the locale default numbering system, or "latn" if the locale
+// has no data, as it's the CLDR root locale default.
+func (g *genericLocale) NumberingSystem() string {
+	if len(g.table[numberingSystemField]) == 0 || g.table[numberingSystemField][0] == "" {
+		return "latn"
+	}
+	return g.table[numberingSystemField][0]
+}
+
+func (g *genericLocale) TimeZoneFormats() []string {
+	return g.table[timeZoneFormatsField]
+}
+
+func (g *genericLocale) LongTimeZoneNames() []string {
+	return g.table[longZoneNamesField]
+}
+
+func (g *genericLocale) ShortTimeZoneNames() []string {
+	return g.table[shortZoneNamesField]
+}
+
+func (g *genericLocale) ExemplarCities() []string {
+	return g.table[exemplarCitiesField]
+}
+
+func (g *genericLocale) LongRelativeTimes() []string {
+	return g.table[longRelativeTimesField]
+}
+
+func (g *genericLocale) ShortRelativeTimes() []string {
+	return g.table[shortRelativeTimesField]
+}
+
+func (g *genericLocale) NarrowRelativeTimes() []string {
+	return g.table[narrowRelativeTimesField]
+}
+
+func (g *genericLocale) PluralRules() []string {
+	return g.table[pluralRulesField]
+}
+
+func (g *genericLocale) DateFormats() []string {
+	return g.table[dateFormatsField]
+}
+
+func (g *genericLocale) TimeFormats() []string {
+	return g.table[timeFormatsField]
+}
+
+func (g *genericLocale) DateTimeFormats() []string {
+	return g.table[dateTimeFormatsField]
+}
+
+func (g *genericLocale) HourCycle() string {
+	if len(g.table[hourCycleField]) == 0 {
+		return ""
+	}
+	return g.table[hourCycleField][0]
+}
+
 func (g *genericLocale) Language() string {
 	return g.lang
 }
@@ -108,6 +425,6 @@ func NewDefaultLocale(lang string) (Locale, error) {
 		return nil, &ErrUnsupportedLocale{lang}
 	}
 
-	locale := genericLocale{lang: lang, table: table}
+	locale := genericLocale{lang: lang, table: table, tries: getLocaleTries(lang)}
 	return &locale, nil
 }
@@ -29,15 +29,69 @@ func TestLocaleTableFieldsLookups(t *testing.T) {
 	shortMonthNamesVal := strconv.Itoa(shortMonthNamesField)
 	longMonthNamesVal := strconv.Itoa(longMonthNamesField)
 	dayPeriodsVal := strconv.Itoa(dayPeriodsField)
+	standAloneShortDayNamesVal := strconv.Itoa(standAloneShortDayNamesField)
+	standAloneLongDayNamesVal := strconv.Itoa(standAloneLongDayNamesField)
+	standAloneShortMonthNamesVal := strconv.Itoa(standAloneShortMonthNamesField)
+	standAloneLongMonthNamesVal := strconv.Itoa(standAloneLongMonthNamesField)
+	minDayNamesVal := strconv.Itoa(minDayNamesField)
+	narrowDayNamesVal := strconv.Itoa(narrowDayNamesField)
+	narrowMonthNamesVal := strconv.Itoa(narrowMonthNamesField)
+	longEraNamesVal := strconv.Itoa(longEraNamesField)
+	shortEraNamesVal := strconv.Itoa(shortEraNamesField)
+	narrowEraNamesVal := strconv.Itoa(narrowEraNamesField)
+	longFlexibleDayPeriodsVal := strconv.Itoa(longFlexibleDayPeriodsField)
+	shortFlexibleDayPeriodsVal := strconv.Itoa(shortFlexibleDayPeriodsField)
+	narrowFlexibleDayPeriodsVal := strconv.Itoa(narrowFlexibleDayPeriodsField)
+	dayPeriodRulesVal := strconv.Itoa(dayPeriodRulesField)
+	numberingSystemVal := strconv.Itoa(numberingSystemField)
+	timeZoneFormatsVal := strconv.Itoa(timeZoneFormatsField)
+	longZoneNamesVal := strconv.Itoa(longZoneNamesField)
+	shortZoneNamesVal := strconv.Itoa(shortZoneNamesField)
+	exemplarCitiesVal := strconv.Itoa(exemplarCitiesField)
+	longRelativeTimesVal := strconv.Itoa(longRelativeTimesField)
+	shortRelativeTimesVal := strconv.Itoa(shortRelativeTimesField)
+	narrowRelativeTimesVal := strconv.Itoa(narrowRelativeTimesField)
+	pluralRulesVal := strconv.Itoa(pluralRulesField)
+	dateFormatsVal := strconv.Itoa(dateFormatsField)
+	timeFormatsVal := strconv.Itoa(timeFormatsField)
+	dateTimeFormatsVal := strconv.Itoa(dateTimeFormatsField)
+	hourCycleVal := strconv.Itoa(hourCycleField)
 
 	locale := genericLocale{
 		lang: LocaleEn,
-		table: [5][]string{
+		table: [localeTableSize][]string{
 			{shortDaysNameVal},
 			{longDayNamesVal},
 			{shortMonthNamesVal},
 			{longMonthNamesVal},
 			{dayPeriodsVal},
+			{standAloneShortDayNamesVal},
+			{standAloneLongDayNamesVal},
+			{standAloneShortMonthNamesVal},
+			{standAloneLongMonthNamesVal},
+			{minDayNamesVal},
+			{narrowDayNamesVal},
+			{narrowMonthNamesVal},
+			{longEraNamesVal},
+			{shortEraNamesVal},
+			{narrowEraNamesVal},
+			{longFlexibleDayPeriodsVal},
+			{shortFlexibleDayPeriodsVal},
+			{narrowFlexibleDayPeriodsVal},
+			{dayPeriodRulesVal},
+			{numberingSystemVal},
+			{timeZoneFormatsVal},
+			{longZoneNamesVal},
+			{shortZoneNamesVal},
+			{exemplarCitiesVal},
+			{longRelativeTimesVal},
+			{shortRelativeTimesVal},
+			{narrowRelativeTimesVal},
+			{pluralRulesVal},
+			{dateFormatsVal},
+			{timeFormatsVal},
+			{dateTimeFormatsVal},
+			{hourCycleVal},
 		},
 	}
 
@@ -65,6 +119,112 @@ func TestLocaleTableFieldsLookups(t *testing.T) {
 		t.Errorf("expected: %s, got: %s", locale.DayPeriods()[0], dayPeriodsVal)
 	}
 
+	if locale.StandAloneShortDayNames()[0] != standAloneShortDayNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.StandAloneShortDayNames()[0], standAloneShortDayNamesVal)
+	}
+
+	if locale.StandAloneLongDayNames()[0] != standAloneLongDayNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.StandAloneLongDayNames()[0], standAloneLongDayNamesVal)
+	}
+
+	if locale.StandAloneShortMonthNames()[0] != standAloneShortMonthNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.StandAloneShortMonthNames()[0], standAloneShortMonthNamesVal)
+	}
+
+	if locale.StandAloneLongMonthNames()[0] != standAloneLongMonthNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.StandAloneLongMonthNames()[0], standAloneLongMonthNamesVal)
+	}
+
+	if locale.MinDayNames()[0] != minDayNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.MinDayNames()[0], minDayNamesVal)
+	}
+
+	if locale.NarrowDayNames()[0] != narrowDayNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.NarrowDayNames()[0], narrowDayNamesVal)
+	}
+
+	if locale.NarrowMonthNames()[0] != narrowMonthNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.NarrowMonthNames()[0], narrowMonthNamesVal)
+	}
+	if locale.LongEraNames()[0] != longEraNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.LongEraNames()[0], longEraNamesVal)
+	}
+
+	if locale.ShortEraNames()[0] != shortEraNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.ShortEraNames()[0], shortEraNamesVal)
+	}
+
+	if locale.NarrowEraNames()[0] != narrowEraNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.NarrowEraNames()[0], narrowEraNamesVal)
+	}
+
+	if locale.LongFlexibleDayPeriods()[0] != longFlexibleDayPeriodsVal {
+		t.Errorf("expected: %s, got: %s", locale.LongFlexibleDayPeriods()[0], longFlexibleDayPeriodsVal)
+	}
+
+	if locale.ShortFlexibleDayPeriods()[0] != shortFlexibleDayPeriodsVal {
+		t.Errorf("expected: %s, got: %s", locale.ShortFlexibleDayPeriods()[0], shortFlexibleDayPeriodsVal)
+	}
+
+	if locale.NarrowFlexibleDayPeriods()[0] != narrowFlexibleDayPeriodsVal {
+		t.Errorf("expected: %s, got: %s", locale.NarrowFlexibleDayPeriods()[0], narrowFlexibleDayPeriodsVal)
+	}
+
+	if locale.DayPeriodRules()[0] != dayPeriodRulesVal {
+		t.Errorf("expected: %s, got: %s", locale.DayPeriodRules()[0], dayPeriodRulesVal)
+	}
+
+	if locale.NumberingSystem() != numberingSystemVal {
+		t.Errorf("expected: %s, got: %s", locale.NumberingSystem(), numberingSystemVal)
+	}
+
+	if locale.TimeZoneFormats()[0] != timeZoneFormatsVal {
+		t.Errorf("expected: %s, got: %s", locale.TimeZoneFormats()[0], timeZoneFormatsVal)
+	}
+
+	if locale.LongTimeZoneNames()[0] != longZoneNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.LongTimeZoneNames()[0], longZoneNamesVal)
+	}
+
+	if locale.ShortTimeZoneNames()[0] != shortZoneNamesVal {
+		t.Errorf("expected: %s, got: %s", locale.ShortTimeZoneNames()[0], shortZoneNamesVal)
+	}
+
+	if locale.ExemplarCities()[0] != exemplarCitiesVal {
+		t.Errorf("expected: %s, got: %s", locale.ExemplarCities()[0], exemplarCitiesVal)
+	}
+
+	if locale.LongRelativeTimes()[0] != longRelativeTimesVal {
+		t.Errorf("expected: %s, got: %s", locale.LongRelativeTimes()[0], longRelativeTimesVal)
+	}
+
+	if locale.ShortRelativeTimes()[0] != shortRelativeTimesVal {
+		t.Errorf("expected: %s, got: %s", locale.ShortRelativeTimes()[0], shortRelativeTimesVal)
+	}
+
+	if locale.NarrowRelativeTimes()[0] != narrowRelativeTimesVal {
+		t.Errorf("expected: %s, got: %s", locale.NarrowRelativeTimes()[0], narrowRelativeTimesVal)
+	}
+
+	if locale.PluralRules()[0] != pluralRulesVal {
+		t.Errorf("expected: %s, got: %s", locale.PluralRules()[0], pluralRulesVal)
+	}
+
+	if locale.DateFormats()[0] != dateFormatsVal {
+		t.Errorf("expected: %s, got: %s", locale.DateFormats()[0], dateFormatsVal)
+	}
+
+	if locale.TimeFormats()[0] != timeFormatsVal {
+		t.Errorf("expected: %s, got: %s", locale.TimeFormats()[0], timeFormatsVal)
+	}
+
+	if locale.DateTimeFormats()[0] != dateTimeFormatsVal {
+		t.Errorf("expected: %s, got: %s", locale.DateTimeFormats()[0], dateTimeFormatsVal)
+	}
+
+	if locale.HourCycle() != hourCycleVal {
+		t.Errorf("expected: %s, got: %s", locale.HourCycle(), hourCycleVal)
+	}
 }
 
 func TestUnsupportedLocale(t *testing.T) {
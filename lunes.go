@@ -20,9 +20,11 @@ package lunes
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 var longDayNamesStd = []string{
@@ -85,12 +87,28 @@ var dayPeriodsStdLower = []string{
 	"pm",
 }
 
+var shortErasStd = []string{
+	"BC",
+	"AD",
+}
+
+var longErasStd = []string{
+	"Before Christ",
+	"Anno Domini",
+}
+
+// the time package handles the era layout elements as literals, so
+// these are the values translated for parsing, instead of the names.
+var shortErasLiteral = []string{"AD", "AD"}
+var longErasLiteral = []string{"Anno Domini", "Anno Domini"}
+
 // Parse parses a formatted string in foreign language and returns the [time.Time] value
 // it represents. See the documentation for the constant called [time.Layout] to see how to
 // represent the format.
 //
-// After translating the foreign language value to English, it gets the time value by
-// calling the Go standard [time.Parse] function.
+// The value is parsed in a single pass, matching the localized names in place. It returns
+// the same time value, and the same errors, as translating the foreign language value to
+// English with [Translate], and calling the Go standard [time.Parse] function with the result.
 //
 // The language argument must be a well-formed BCP 47 language tag, e.g ("en", "en-US") and
 // a known locale. If no data is found for the language, it returns ErrUnsupportedLocale.
@@ -113,16 +131,12 @@ func Parse(layout string, value string, lang string) (time.Time, error) {
 // it receives a built [lunes.Locale], avoiding looking up existing data in each operation
 // and allowing extensibility.
 func ParseWithLocale(layout string, value string, locale Locale) (time.Time, error) {
-	pv, err := TranslateWithLocale(layout, value, locale)
-	if err != nil {
-		return time.Time{}, err
-	}
-
-	return time.Parse(layout, pv)
+	return parse(layout, nil, value, nil, locale, &Options{})
 }
 
 // ParseInLocation is like Parse, but it interprets the time as in the given location.
-// In addition to the [Parse] errors, it might return any [time.ParseInLocation] possible errors.
+// It returns the same time value as calling [time.ParseInLocation] with the translated value,
+// and in addition to the [Parse] errors, it might return any of its possible errors.
 // To execute several parses for the same locale, use [ParseInLocationWithLocale] as it performs better.
 func ParseInLocation(layout string, value string, lang string, location *time.Location) (time.Time, error) {
 	locale, err := NewDefaultLocale(lang)
@@ -137,12 +151,118 @@ func ParseInLocation(layout string, value string, lang string, location *time.Lo
 // language tag argument, it receives a built [lunes.Locale], avoiding looking up existing
 // data in each operation and allowing extensibility.
 func ParseInLocationWithLocale(layout string, value string, location *time.Location, locale Locale) (time.Time, error) {
-	pv, err := TranslateWithLocale(layout, value, locale)
+	return parse(layout, nil, value, location, locale, &Options{})
+}
+
+// parse is like parseValue, returning the errors as ErrParse errors locating the mismatch
+// in the value.
+func parse(layout string, elems []layoutElem, value string, location *time.Location, locale Locale, opts *Options) (time.Time, error) {
+	t, err := parseValue(layout, elems, value, location, locale, opts)
+	if err != nil {
+		return time.Time{}, newParseError(layout, elems, value, locale, opts, err)
+	}
+
+	return t, nil
+}
+
+// parseValue parses the value in a single pass by parseNative, or if it does not parse, by
+// parseTranslated. Both return the same results, but only the latter returns errors, unless
+// the options read the value differently than the time package.
+func parseValue(layout string, elems []layoutElem, value string, location *time.Location, locale Locale, opts *Options) (time.Time, error) {
+	if t, ok := parseNative(layout, elems, value, location, locale, opts); ok {
+		return t, nil
+	}
+
+	if opts.readsValue() {
+		if err := nativeMismatchError(layout, elems, value, locale, opts); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return parseTranslated(layout, elems, value, location, locale, opts)
+}
+
+// parseTranslated translates the value and parses it using the Go standard [time.ParseInLocation],
+// or [time.Parse] if the location is nil, applying the translated values that are not
+// supported by the time package, such as eras. The layout elements are found on the fly
+// if elems is nil.
+func parseTranslated(layout string, elems []layoutElem, value string, location *time.Location, locale Locale, opts *Options) (time.Time, error) {
+	state := parseState{yearOffset: -1}
+	pv, err := translateElems(layout, elems, value, locale, opts, &state)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := state.parse(layout, pv, location)
+	if err != nil && opts.IgnoreTrailingInput {
+		if pv, ok := trimExtraText(pv, err); ok {
+			return state.parse(layout, pv, location)
+		}
+	}
+
+	return t, err
+}
+
+// trimExtraText returns the translated value without the extra text the time package
+// reported in the err, if it did.
+func trimExtraText(value string, err error) (string, bool) {
+	var pe *time.ParseError
+	if !errors.As(err, &pe) || !strings.HasPrefix(pe.Message, ": extra text") || !strings.HasSuffix(value, pe.ValueElem) {
+		return value, false
+	}
+	return value[:len(value)-len(pe.ValueElem)], true
+}
+
+// parseState holds the values read by translate that are not supported by the time
+// package parsing functions, and must be applied to the parsed time.
+type parseState struct {
+	// bc indicates that the value era is before the common era.
+	bc bool
+	// year holds the value of the 2006 layout element, when combined with eras, and
+	// yearOffset its position on the translated value, or -1 if it was not read.
+	year       int
+	yearOffset int
+	// location holds the localized time zone location, which replaces the parsed one.
+	location *time.Location
+}
+
+// parse parses the translated value using the Go standard [time.ParseInLocation], or
+// [time.Parse] if the location is nil, and applies the state to the parsed time.
+func (s *parseState) parse(layout string, value string, location *time.Location) (time.Time, error) {
+	var t time.Time
+	var err error
+	if location == nil {
+		t, err = time.Parse(layout, value)
+	} else {
+		t, err = time.ParseInLocation(layout, value, location)
+	}
 	if err != nil {
 		return time.Time{}, err
 	}
 
-	return time.ParseInLocation(layout, pv, location)
+	return s.apply(t), nil
+}
+
+func (s *parseState) apply(t time.Time) time.Time {
+	if !s.bc && s.location == nil {
+		return t
+	}
+
+	// there's no year zero on eras, so 1 BC is the proleptic Gregorian year 0,
+	// 2 BC is -1, and so on.
+	year := t.Year()
+	if s.bc && s.yearOffset >= 0 {
+		year = 1 - s.year
+	} else if s.bc {
+		year = 1 - year
+	}
+
+	location := t.Location()
+	if s.location != nil {
+		location = s.location
+	}
+
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
 }
 
 // Translate parses a localized textual time value from the provided locale to English.
@@ -157,6 +277,15 @@ func ParseInLocationWithLocale(layout string, value string, location *time.Locat
 // it results in an ErrUnsupportedLayoutElem error. On the other hand, if the value does
 // not match the layout, an ErrLayoutMismatch is returned.
 //
+// In addition to the [time.Layout] elements, it also translates the lunes specific era
+// layout elements, AD for the abbreviated eras names and Anno Domini for the long ones.
+// They must be whole words, not preceded or followed by letters (e.g. "ADT" is a literal),
+// and are only translated by locales implementing the [EraLocale] interface with eras names,
+// being literals otherwise. When combined with eras, the 2006 layout element accepts years
+// with less than 4 digits (e.g. "44 BC"), translating them zero-padded. As eras are not supported by the time package, values with
+// eras are meant to be parsed by the lunes parsing functions, which sets the years before
+// the common era as negative proleptic Gregorian years (1 BC is year 0, 2 BC is -1).
+//
 // This function is meant to return a value that can be used with the Go standard
 // [time.Parse] or [time.ParseInLocation] methods. Although it maintains value's empty
 // spaces that are not present in the layout string, it might drop them in the future,
@@ -174,222 +303,716 @@ func Translate(layout string, value string, lang string) (string, error) {
 // argument, it receives a built [lunes.Locale], avoiding looking up existing data in each
 // operation and allowing extensibility.
 func TranslateWithLocale(layout string, value string, locale Locale) (string, error) {
+	return translate(layout, value, locale, &Options{}, nil)
+}
+
+// translate translates the value to English. If the state argument is not nil, the
+// translated value is meant to be parsed by the time package, and the values it does
+// not support are stored on the state instead.
+func translate(layout string, value string, locale Locale, opts *Options, state *parseState) (string, error) {
+	return translateElems(layout, nil, value, locale, opts, state)
+}
+
+// translateElems is like translate, but it receives the layout elements found at each
+// layout offset, as returned by newLayoutElems. If elems is nil, they are found on the fly.
+func translateElems(layout string, elems []layoutElem, value string, locale Locale, opts *Options, state *parseState) (string, error) {
+	buf, err := appendTranslateElems(make([]byte, 0, len(layout)+32), layout, elems, value, locale, opts, state)
+	if err != nil {
+		return "", err
+	}
+
+	// the buffer is not modified after this point, as strings.Builder does
+	return bytesString(buf), nil
+}
+
+// appendTranslateElems is like translateElems, but it appends the translated value to dst,
+// returning the extended buffer.
+func appendTranslateElems(dst []byte, layout string, elems []layoutElem, value string, locale Locale, opts *Options, state *parseState) ([]byte, error) {
 	var err error
-	var sb strings.Builder
 	var layoutOffset, valueOffset int
 
-	sb.Grow(len(layout) + 32)
+	// hour holds the 12-hour clock value, used to resolve flexible day periods
+	hour := -1
+	period := pendingDayPeriod{offset: -1}
+
+	// native digits are translated as well, so the time package can parse them
+	value = transliterateDigits(value, locale)
+	if opts.Spaces == LenientSpaces {
+		value = normalizeSpaces(value)
+	}
+
+	// eras are usually combined with years that have less than 4 digits, which the
+	// elements found by newLayoutElems already know.
+	eraLayout := elems == nil && isEraLayout(layout, locale)
+	var found, literalElem layoutElem
 
 	for layoutOffset < len(layout) {
 		written := false
-		var lookupTab, stdTab []string
-
-		switch c := int(layout[layoutOffset]); c {
-		case 'J': // January, Jan
-			if len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "Jan" {
-				layoutElem := ""
-				if len(layout) >= layoutOffset+7 && layout[layoutOffset:layoutOffset+7] == "January" {
-					layoutElem = "January"
-					lookupTab = locale.LongMonthNames()
-					stdTab = longMonthNamesStd
-				} else if !startsWithLowerCase(layout[layoutOffset+3:]) {
-					layoutElem = "Jan"
-					lookupTab = locale.ShortMonthNames()
-					stdTab = shortMonthNamesStd
-				}
 
-				if layoutElem == "" {
-					break
-				}
+		elem := &literalElem
+		if elems != nil {
+			elem = &elems[layoutOffset]
+		} else if startsLayoutElem(layout[layoutOffset]) {
+			setLayoutElem(&found, layout, layoutOffset, locale, opts, eraLayout)
+			elem = &found
+		}
 
-				if len(lookupTab) == 0 {
-					return "", newUnsupportedLayoutElemError(layoutElem, locale)
-				}
+		if elem.err != nil {
+			return dst, elem.err
+		}
 
-				layoutOffset += len(layoutElem)
-				valueOffset, err = writeLayoutValue(layoutElem, lookupTab, stdTab, valueOffset, value, &sb)
-				if err != nil {
-					return "", err
-				}
+		switch elem.kind {
+		case namesLayoutElem: // January, Jan, Monday, Mon
+			layoutOffset += len(elem.name)
+			dst, valueOffset, _, err = appendNamesValue(dst, elem, elem.stdTab, valueOffset, value, opts, false)
+			if err != nil {
+				return dst, err
+			}
 
-				written = true
+			if elem.name == "Jan" || elem.name == "Mon" {
+				valueOffset += len(value[valueOffset:]) - len(skipAbbreviationPeriod(layout, layoutOffset, value[valueOffset:], opts))
+			}
+			written = true
+		case timeZoneLayoutElem: // MST
+			// the time zones names are only matched for parsing, as the translated
+			// value would lose the location
+			if state == nil {
+				break
 			}
-		case 'M': // Monday, Mon
-			if len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "Mon" {
-				layoutElem := ""
-				if len(layout) >= layoutOffset+6 && layout[layoutOffset:layoutOffset+6] == "Monday" {
-					layoutElem = "Monday"
-					lookupTab = locale.LongDayNames()
-					stdTab = longDayNamesStd
-				} else if !startsWithLowerCase(layout[layoutOffset+3:]) {
-					layoutElem = "Mon"
-					lookupTab = locale.ShortDayNames()
-					stdTab = shortDayNamesStd
-				}
 
-				if layoutElem == "" {
-					break
-				}
+			newOffset, skippedSpaces := skipLeadingSpace(value, valueOffset)
+			n, location, err := matchTimeZone(value, newOffset, locale)
+			if err != nil {
+				return dst, err
+			}
+
+			if location == nil {
+				return dst, newLayoutMismatchError("MST", value)
+			}
 
-				if len(lookupTab) == 0 {
-					return "", newUnsupportedLayoutElemError(layoutElem, locale)
+			// the time package parses UTC as is, the location is set afterward
+			dst = appendSpaces(dst, skippedSpaces)
+			dst = append(dst, "UTC"...)
+			state.location = location
+			layoutOffset += 3
+			valueOffset = newOffset + n
+			written = true
+		case dayPeriodLayoutElem: // PM, pm
+			layoutOffset += 2
+
+			// flexible day periods are only used when they match a longer value than
+			// the AM/PM names, as both usually share the am and pm translations.
+			_, _, _, stdMatched, _ := lookupNames(valueOffset, value, elem.lookupTrie, opts, elem.lookupTab)
+			newOffset, skippedSpaces, index, matched, _ := lookupNames(valueOffset, value, elem.trie, opts, elem.lookupTabs()...)
+			if index >= 0 && len(matched) > len(stdMatched) {
+				dst = appendSpaces(dst, skippedSpaces)
+				valueOffset = newOffset + len(matched)
+
+				var rule string
+				if index < len(elem.rules) {
+					rule = elem.rules[index]
 				}
 
-				layoutOffset += len(layoutElem)
-				valueOffset, err = writeLayoutValue(layoutElem, lookupTab, stdTab, valueOffset, value, &sb)
-				if err != nil {
-					return "", err
+				// the period is resolved once the hour is known
+				period = pendingDayPeriod{offset: len(dst), layoutElem: elem.name, index: index, rule: rule, stdTab: elem.stdTab}
+				if p, ok := period.resolve(-1); ok {
+					dst = append(dst, elem.stdTab[p]...)
+					period = pendingDayPeriod{offset: -1}
 				}
 				written = true
+				break
 			}
-		case 'P', 'p': // PM, pm
-			if len(layout) >= layoutOffset+2 && unicode.ToUpper(rune(layout[layoutOffset+1])) == 'M' {
-				var layoutElem string
-				// day-periods case matters for the time package parsing functions
-				if c == 'p' {
-					layoutElem = "pm"
-					stdTab = dayPeriodsStdLower
-				} else {
-					layoutElem = "PM"
-					stdTab = dayPeriodsStdUpper
-				}
 
-				lookupTab = locale.DayPeriods()
-				if len(lookupTab) == 0 {
-					return "", newUnsupportedLayoutElemError(layoutElem, locale)
-				}
+			dst, valueOffset, _, err = appendNamesValue(dst, elem, elem.stdTab, valueOffset, value, opts, true)
+			if err != nil {
+				return dst, err
+			}
+			written = true
+		case eraLayoutElem: // Anno Domini, AD
+			stdTab := elem.stdTab
+			if state != nil {
+				stdTab = elem.parseStdTab
+			}
 
-				layoutOffset += 2
-				valueOffset, err = writeLayoutValue(layoutElem, lookupTab, stdTab, valueOffset, value, &sb)
-				if err != nil {
-					return "", err
-				}
-				written = true
+			var era int
+			layoutOffset += len(elem.name)
+			dst, valueOffset, era, err = appendNamesValue(dst, elem, stdTab, valueOffset, value, opts, false)
+			if err != nil {
+				return dst, err
+			}
+
+			if state != nil {
+				state.bc = era == 0
+			}
+			written = true
+		case eraYearLayoutElem: // 2006
+			layoutOffset += 4
+			dst, valueOffset, err = appendEraYearDigits(dst, value, valueOffset, state)
+			if err != nil {
+				return dst, err
 			}
-		case '_': // _2, _2006, __2
+			written = true
+		case paddedLayoutElem: // _2, _2006, __2
 			// Although no translations happen here, it is still necessary to calculate the
 			// variable size of `_` values, so the layoutOffset stays synchronized with
 			// its layout element counterpart.
-			if len(layout) >= layoutOffset+2 && layout[layoutOffset+1] == '2' {
-				var layoutElem string
-				// _2006 is really a literal _, followed by the long year placeholder
-				if len(layout) >= layoutOffset+5 && layout[layoutOffset+1:layoutOffset+5] == "2006" {
-					if len(value) >= valueOffset+5 {
-						layoutElem = "_2006"
-					}
-				} else {
-					if len(value) >= valueOffset+2 {
-						layoutElem = "_2"
-					}
-				}
-
-				layoutElemSize := len(layoutElem)
-				if layoutElemSize > 0 {
-					layoutOffset += layoutElemSize
-					valueOffset, err = writeNextNonSpaceValue(value, valueOffset, layoutElemSize, layoutElem, &sb)
-					if err != nil {
-						return "", err
-					}
-					written = true
+			if len(value) >= valueOffset+len(elem.name) {
+				layoutOffset += len(elem.name)
+				dst, valueOffset, err = appendNextNonSpaceValue(dst, value, valueOffset, len(elem.name), elem.name)
+				if err != nil {
+					return dst, err
 				}
+				written = true
+			}
+		case paddedHourLayoutElem: // 03
+			// the two-digit hour is copied as a literal, but its value is kept to
+			// resolve flexible day periods.
+			if h, err := strconv.Atoi(value[valueOffset:min(valueOffset+2, len(value))]); err == nil {
+				hour = h
+			}
+		case hourLayoutElem: // 15
+			// the time package accepts one or two digits hours, as for the 3 layout element
+			dst, valueOffset, err = appendFlexibleClockDigits(dst, '1', value, valueOffset)
+			if err != nil {
+				return dst, newLayoutMismatchError("15", value)
+			}
+			layoutOffset += 2
+			written = true
+		case clockLayoutElem: // 1, 2, 3, 4, 5
+			digitsOffset := len(dst)
+			dst, valueOffset, err = appendFlexibleClockDigits(dst, elem.name[0], value, valueOffset)
+			if err != nil {
+				return dst, err
 			}
 
-			if len(layout) >= layoutOffset+3 && layout[layoutOffset+1] == '_' && layout[layoutOffset+2] == '2' {
-				if len(value) >= valueOffset+3 {
-					layoutOffset += 3
-					valueOffset, err = writeNextNonSpaceValue(value, valueOffset, 3, "__2", &sb)
-					if err != nil {
-						return "", err
-					}
-					written = true
-				}
+			if elem.name == "3" {
+				hour = clockDigits(dst[digitsOffset:])
 			}
-		case '3', '4', '5': // variable-width h/m/s from reference time, skip when second digit of 03/04/05
-			if layoutOffset == 0 || layout[layoutOffset-1] != '0' {
-				layoutElem := layout[layoutOffset]
-				valueOffset, err = writeFlexibleClockDigits(layoutElem, value, valueOffset, &sb)
-				if err != nil {
-					return "", err
-				}
-				layoutOffset++
-				written = true
+			layoutOffset++
+			written = true
+		}
+
+		if !written && opts.Spaces == DefaultSpaces && layout[layoutOffset] == ' ' && valueOffset < len(value) && value[valueOffset] == ' ' {
+			// a layout spaces run matches a value spaces run, as the time package reads
+			// them, so the elements that follow stay synchronized with the value
+			spaces := value[valueOffset : len(value)-len(strings.TrimLeft(value[valueOffset:], " "))]
+			dst = append(dst, spaces...)
+			valueOffset += len(spaces)
+			for layoutOffset++; layoutOffset < len(layout) && layout[layoutOffset] == ' '; layoutOffset++ {
 			}
+			written = true
 		}
 
 		if !written {
-			var writtenSize int
+			// literals are copied byte by byte, so multi-byte characters stay intact
 			if len(value) > valueOffset {
-				writtenSize, err = sb.WriteRune(rune(value[valueOffset]))
-				if err != nil {
-					return "", err
-				}
+				dst = append(dst, value[valueOffset])
+				valueOffset++
 			}
 
 			layoutOffset++
-			valueOffset += writtenSize
 		}
 	}
 
 	if len(value) >= valueOffset {
-		sb.WriteString(value[valueOffset:])
+		dst = append(dst, value[valueOffset:]...)
 	}
 
-	return sb.String(), nil
+	if state != nil && state.bc && state.yearOffset >= 0 {
+		replaceBCYear(dst, state)
+	}
+
+	if period.offset >= 0 {
+		p, ok := period.resolve(hour)
+		if !ok {
+			return dst, newLayoutMismatchError(period.layoutElem, value)
+		}
+		dst = insertString(dst, period.offset, period.stdTab[p])
+	}
+
+	return dst, nil
 }
 
-func writeFlexibleClockDigits(layoutElem byte, value string, valueOffset int, sb *strings.Builder) (int, error) {
-	newOffset, skippedSpaces := skipLeadingSpace(value, valueOffset)
-	if newOffset >= len(value) || value[newOffset] < '0' || value[newOffset] > '9' {
-		return valueOffset, newLayoutMismatchError(string(layoutElem), value)
+// layoutElemKind is the kind of the layout elements handled by translate.
+type layoutElemKind uint8
+
+const (
+	// literalLayoutElem is a layout byte copied as is.
+	literalLayoutElem layoutElemKind = iota
+	namesLayoutElem
+	timeZoneLayoutElem
+	dayPeriodLayoutElem
+	eraLayoutElem
+	eraYearLayoutElem
+	paddedLayoutElem
+	paddedHourLayoutElem
+	hourLayoutElem
+	clockLayoutElem
+)
+
+// layoutElem is a layout element starting at a layout offset, holding the locale tables
+// it is translated with.
+type layoutElem struct {
+	kind layoutElemKind
+	name string
+	// lookupTab holds the AM/PM day periods, and tabs the names tables, or the flexible
+	// day periods tables for day periods. The tables are held in an array, so elements
+	// found on the fly by translate stay on the stack.
+	lookupTab []string
+	tabs      [6][]string
+	tabsLen   int
+	// lookupTrie and trie hold the tries of lookupTab and tabs, if the locale has them.
+	lookupTrie *nameTrie
+	trie       *nameTrie
+	stdTab     []string
+	// parseStdTab holds the values translated for parsing, if they differ from stdTab.
+	parseStdTab []string
+	// rules holds the flexible day periods rules.
+	rules []string
+	// fallbacks holds the element of each fallback locale supporting it, matched when the
+	// element names do not match the value, and language the fallback element locale one.
+	fallbacks []layoutElem
+	language  string
+	// err holds the error returned when the element is reached, such as unsupported
+	// layout elements.
+	err error
+}
+
+// lookupTabs returns the element lookup tables.
+func (e *layoutElem) lookupTabs() [][]string {
+	return e.tabs[:e.tabsLen]
+}
+
+// startsLayoutElem reports whether the byte might start a layout element handled by translate.
+func startsLayoutElem(c byte) bool {
+	switch c {
+	case 'J', 'M', 'P', 'p', 'A', '2', '_', '0', '1', '3', '4', '5':
+		return true
 	}
+	return false
+}
 
-	end := newOffset + 1
-	if end < len(value) && value[end] >= '0' && value[end] <= '9' {
+// isEraLayout reports whether the layout contains era elements, and the locale has era names
+// to translate them. Otherwise, AD and Anno Domini are literals, and the 2006 elements are
+// read as the time package does.
+func isEraLayout(layout string, locale Locale) bool {
+	if eras, ok := locale.(EraLocale); !ok || len(eras.LongEraNames()) == 0 && len(eras.ShortEraNames()) == 0 {
+		return false
+	}
+
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == 'A' && eraLayoutElemAt(layout, i) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eraLayoutElemAt returns the era layout element (Anno Domini or AD) starting at the layout
+// offset, or an empty string if there is none. The elements are whole words, so literals
+// such as the "ADT" time zone or "MADRID" are not read as eras.
+func eraLayoutElemAt(layout string, layoutOffset int) string {
+	var elem string
+	if strings.HasPrefix(layout[layoutOffset:], "Anno Domini") {
+		elem = "Anno Domini"
+	} else if strings.HasPrefix(layout[layoutOffset:], "AD") {
+		elem = "AD"
+	} else {
+		return ""
+	}
+
+	if before, _ := utf8.DecodeLastRuneInString(layout[:layoutOffset]); unicode.IsLetter(before) {
+		return ""
+	}
+	if after, _ := utf8.DecodeRuneInString(layout[layoutOffset+len(elem):]); unicode.IsLetter(after) {
+		return ""
+	}
+
+	return elem
+}
+
+// newLayoutElems returns the layout elements starting at each layout offset, and the error
+// of the first unsupported element.
+func newLayoutElems(layout string, locale Locale, opts *Options) ([]layoutElem, error) {
+	eraLayout := isEraLayout(layout, locale)
+	elems := make([]layoutElem, len(layout))
+	for i := range elems {
+		setLayoutElem(&elems[i], layout, i, locale, opts, eraLayout)
+	}
+
+	for i := 0; i < len(elems); {
+		if elems[i].err != nil {
+			return nil, elems[i].err
+		}
+		i += max(len(elems[i].name), 1)
+	}
+
+	return elems, nil
+}
+
+// setLayoutElem sets elem to the layout element starting at the layout offset. Elements
+// whose translation depends on the value or the parsing state are set as is, and translate
+// falls back to copying a literal when they don't apply.
+func setLayoutElem(elem *layoutElem, layout string, layoutOffset int, locale Locale, opts *Options, eraLayout bool) {
+	var kind layoutElemKind
+	var name string
+	var lookupTab, stdTab, parseStdTab, rules []string
+	var lookupTabs [][]string
+
+	switch c := int(layout[layoutOffset]); c {
+	case 'J': // January, Jan
+		if len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "Jan" {
+			if len(layout) >= layoutOffset+7 && layout[layoutOffset:layoutOffset+7] == "January" {
+				kind, name, stdTab = namesLayoutElem, "January", longMonthNamesStd
+				lookupTabs = monthNamesTabs(elem.tabs[:0], locale, true, opts)
+			} else if !startsWithLowerCase(layout[layoutOffset+3:]) {
+				kind, name, stdTab = namesLayoutElem, "Jan", shortMonthNamesStd
+				lookupTabs = monthNamesTabs(elem.tabs[:0], locale, false, opts)
+			}
+		}
+	case 'M': // Monday, Mon, MST
+		if opts.TimeZoneNames && len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "MST" {
+			*elem = layoutElem{kind: timeZoneLayoutElem, name: "MST"}
+			return
+		}
+
+		if len(layout) >= layoutOffset+3 && layout[layoutOffset:layoutOffset+3] == "Mon" {
+			if len(layout) >= layoutOffset+6 && layout[layoutOffset:layoutOffset+6] == "Monday" {
+				kind, name, stdTab = namesLayoutElem, "Monday", longDayNamesStd
+				lookupTabs = dayNamesTabs(elem.tabs[:0], locale, true, opts)
+			} else if !startsWithLowerCase(layout[layoutOffset+3:]) {
+				kind, name, stdTab = namesLayoutElem, "Mon", shortDayNamesStd
+				lookupTabs = dayNamesTabs(elem.tabs[:0], locale, false, opts)
+			}
+		}
+	case 'P', 'p': // PM, pm
+		if len(layout) >= layoutOffset+2 && unicode.ToUpper(rune(layout[layoutOffset+1])) == 'M' {
+			// day-periods case matters for the time package parsing functions
+			if c == 'p' {
+				kind, name, stdTab = dayPeriodLayoutElem, "pm", dayPeriodsStdLower
+			} else {
+				kind, name, stdTab = dayPeriodLayoutElem, "PM", dayPeriodsStdUpper
+			}
+
+			lookupTab = locale.DayPeriods()
+			lookupTabs = flexibleDayPeriodsTabs(elem.tabs[:0], locale)
+			if periods, ok := locale.(FlexibleDayPeriodLocale); ok {
+				rules = periods.DayPeriodRules()
+			}
+		}
+	case 'A': // Anno Domini, AD
+		if !eraLayout {
+			break
+		}
+
+		switch eraLayoutElemAt(layout, layoutOffset) {
+		case "Anno Domini":
+			kind, name, stdTab, parseStdTab = eraLayoutElem, "Anno Domini", longErasStd, longErasLiteral
+			lookupTabs = eraNamesTabs(elem.tabs[:0], locale, true, opts)
+		case "AD":
+			kind, name, stdTab, parseStdTab = eraLayoutElem, "AD", shortErasStd, shortErasLiteral
+			lookupTabs = eraNamesTabs(elem.tabs[:0], locale, false, opts)
+		}
+	case '2': // 2006, 2
+		if len(layout) >= layoutOffset+4 && layout[layoutOffset:layoutOffset+4] == "2006" {
+			if eraLayout {
+				*elem = layoutElem{kind: eraYearLayoutElem, name: "2006"}
+				return
+			}
+			break
+		}
+
+		// variable-width day, skip when second digit of 02 or third of 002
+		if layoutOffset == 0 || layout[layoutOffset-1] != '0' {
+			*elem = layoutElem{kind: clockLayoutElem, name: "2"}
+			return
+		}
+	case '_': // _2, _2006, __2
+		// _2006 is really a literal _, followed by the long year placeholder
+		if len(layout) >= layoutOffset+5 && layout[layoutOffset+1:layoutOffset+5] == "2006" {
+			*elem = layoutElem{kind: paddedLayoutElem, name: "_2006"}
+			return
+		}
+
+		if len(layout) >= layoutOffset+2 && layout[layoutOffset+1] == '2' {
+			*elem = layoutElem{kind: paddedLayoutElem, name: "_2"}
+			return
+		}
+
+		if len(layout) >= layoutOffset+3 && layout[layoutOffset+1] == '_' && layout[layoutOffset+2] == '2' {
+			*elem = layoutElem{kind: paddedLayoutElem, name: "__2"}
+			return
+		}
+	case '0': // 03
+		if len(layout) >= layoutOffset+2 && layout[layoutOffset+1] == '3' {
+			*elem = layoutElem{kind: paddedHourLayoutElem}
+			return
+		}
+	case '1': // 15, 1
+		if len(layout) >= layoutOffset+2 && layout[layoutOffset+1] == '5' {
+			*elem = layoutElem{kind: hourLayoutElem, name: "15"}
+			return
+		}
+
+		// variable-width month, skip when second digit of 01
+		if layoutOffset == 0 || layout[layoutOffset-1] != '0' {
+			*elem = layoutElem{kind: clockLayoutElem, name: "1"}
+			return
+		}
+	case '3', '4', '5': // variable-width h/m/s from reference time, skip when second digit of 03/04/05
+		if layoutOffset == 0 || layout[layoutOffset-1] != '0' {
+			*elem = layoutElem{kind: clockLayoutElem, name: layout[layoutOffset : layoutOffset+1]}
+			return
+		}
+	}
+
+	if kind == literalLayoutElem {
+		*elem = layoutElem{}
+		return
+	}
+
+	var err error
+	if len(lookupTab) == 0 && allEmpty(lookupTabs) {
+		err = newUnsupportedLayoutElemError(name, locale)
+	}
+
+	elem.kind = kind
+	elem.name = name
+	elem.lookupTab = lookupTab
+	elem.tabsLen = len(lookupTabs)
+	elem.lookupTrie = nil
+	elem.trie = nil
+	if g, ok := locale.(*genericLocale); ok && g.tries != nil {
+		elem.lookupTrie = g.namesTrie(lookupTab)
+		elem.trie = g.namesTrie(lookupTabs...)
+	}
+	elem.stdTab = stdTab
+	elem.parseStdTab = parseStdTab
+	elem.rules = rules
+	elem.err = err
+	elem.fallbacks = nil
+	elem.language = ""
+	if opts.hasFallbacks() {
+		elem.fallbacks = fallbackLayoutElems(layout, layoutOffset, opts, eraLayout)
+	}
+}
+
+// appendEraYearDigits appends the variable-width year digits, padding them to the 4 digits
+// expected by the 2006 layout element.
+func appendEraYearDigits(dst []byte, value string, valueOffset int, state *parseState) ([]byte, int, error) {
+	newOffset, skippedSpaces := skipLeadingSpace(value, valueOffset)
+	end := newOffset
+	for end < len(value) && value[end] >= '0' && value[end] <= '9' {
 		end++
 	}
 
-	if skippedSpaces > 0 {
-		sb.WriteString(strings.Repeat(" ", skippedSpaces))
+	if end == newOffset {
+		return dst, valueOffset, newLayoutMismatchError("2006", value)
 	}
-	sb.WriteString(value[newOffset:end])
-	return end, nil
+
+	dst = appendSpaces(dst, skippedSpaces)
+
+	if state != nil {
+		state.year, _ = strconv.Atoi(value[newOffset:end])
+		state.yearOffset = len(dst)
+	}
+
+	for i := end - newOffset; i < 4; i++ {
+		dst = append(dst, '0')
+	}
+	dst = append(dst, value[newOffset:end]...)
+	return dst, end, nil
 }
 
-func writeNextNonSpaceValue(value string, offset int, max int, layoutElem string, sb *strings.Builder) (int, error) {
-	nextValOffset, skippedSpaces, val, err := nextNonSpaceValue(value, offset, max, layoutElem)
-	if err != nil {
-		return offset, err
+// replaceBCYear replaces the translated year digits by a year with the same leap year
+// rules of the proleptic Gregorian BC year, so the time package validates the days of
+// February correctly. The actual year is set by parseState.apply after parsing it.
+func replaceBCYear(value []byte, state *parseState) {
+	if len(value) < state.yearOffset+4 {
+		return
 	}
 
-	if skippedSpaces > 0 {
-		val = strings.Repeat(" ", skippedSpaces) + val
+	year := "2001"
+	if isLeap(1 - state.year) {
+		year = "2000"
 	}
 
-	_, err = sb.WriteString(val)
-	if err != nil {
-		return offset, err
+	copy(value[state.yearOffset:], year)
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// monthNamesTabs appends to tabs the locale tables matched by the long (January) or
+// short (Jan) month names layout elements, or both if the options accept any width.
+func monthNamesTabs(tabs [][]string, locale Locale, long bool, opts *Options) [][]string {
+	standAlone, isStandAlone := locale.(StandAloneLocale)
+	if long || opts.AnyWidthNames {
+		tabs = append(tabs, locale.LongMonthNames())
+		if isStandAlone {
+			tabs = append(tabs, standAlone.StandAloneLongMonthNames())
+		}
 	}
 
-	return nextValOffset, nil
+	if !long || opts.AnyWidthNames {
+		tabs = append(tabs, locale.ShortMonthNames())
+		if isStandAlone {
+			tabs = append(tabs, standAlone.StandAloneShortMonthNames())
+		}
+
+		if opts.NarrowNames {
+			if narrow, ok := locale.(NarrowLocale); ok {
+				tabs = append(tabs, narrow.NarrowMonthNames())
+			}
+		}
+	}
+
+	return tabs
+}
+
+// dayNamesTabs appends to tabs the locale tables matched by the long (Monday) or
+// short (Mon) day names layout elements, or both if the options accept any width.
+func dayNamesTabs(tabs [][]string, locale Locale, long bool, opts *Options) [][]string {
+	standAlone, isStandAlone := locale.(StandAloneLocale)
+	if long || opts.AnyWidthNames {
+		tabs = append(tabs, locale.LongDayNames())
+		if isStandAlone {
+			tabs = append(tabs, standAlone.StandAloneLongDayNames())
+		}
+	}
+
+	if !long || opts.AnyWidthNames {
+		tabs = append(tabs, locale.ShortDayNames())
+		if isStandAlone {
+			tabs = append(tabs, standAlone.StandAloneShortDayNames())
+		}
+
+		if opts.NarrowNames {
+			if narrow, ok := locale.(NarrowLocale); ok {
+				tabs = append(tabs, narrow.MinDayNames(), narrow.NarrowDayNames())
+			}
+		}
+	}
+
+	return tabs
+}
+
+// eraNamesTabs appends to tabs the locale tables matched by the long (Anno Domini) or
+// short (AD) era names layout elements, or both if the options accept any width.
+func eraNamesTabs(tabs [][]string, locale Locale, long bool, opts *Options) [][]string {
+	eras, ok := locale.(EraLocale)
+	if !ok {
+		return tabs
+	}
+
+	if long || opts.AnyWidthNames {
+		tabs = append(tabs, eras.LongEraNames())
+	}
+
+	if !long || opts.AnyWidthNames {
+		tabs = append(tabs, eras.ShortEraNames(), eras.NarrowEraNames())
+	}
+
+	return tabs
+}
+
+// flexibleDayPeriodsTabs appends to tabs the locale flexible day periods tables, matched
+// by the day periods layout elements (PM, pm).
+func flexibleDayPeriodsTabs(tabs [][]string, locale Locale) [][]string {
+	periods, ok := locale.(FlexibleDayPeriodLocale)
+	if !ok {
+		return tabs
+	}
+
+	return append(tabs,
+		periods.LongFlexibleDayPeriods(),
+		periods.ShortFlexibleDayPeriods(),
+		periods.NarrowFlexibleDayPeriods(),
+	)
+}
+
+func allEmpty(tabs [][]string) bool {
+	for _, tab := range tabs {
+		if len(tab) > 0 {
+			return false
+		}
+	}
+	return true
 }
 
-func writeLayoutValue(layoutElem string, lookupTab, stdTab []string, valueOffset int, value string, sb *strings.Builder) (int, error) {
-	newOffset, skippedSpaces, foundStdValue, matched := lookup(lookupTab, valueOffset, value, stdTab)
-	if foundStdValue == "" {
-		return valueOffset, newLayoutMismatchError(layoutElem, value)
+func appendFlexibleClockDigits(dst []byte, layoutElem byte, value string, valueOffset int) ([]byte, int, error) {
+	newOffset, skippedSpaces := skipLeadingSpace(value, valueOffset)
+	if newOffset >= len(value) || value[newOffset] < '0' || value[newOffset] > '9' {
+		return dst, valueOffset, newLayoutMismatchError(string(layoutElem), value)
 	}
 
-	if skippedSpaces > 0 {
-		foundStdValue = strings.Repeat(" ", skippedSpaces) + foundStdValue
+	end := newOffset + 1
+	if end < len(value) && value[end] >= '0' && value[end] <= '9' {
+		end++
 	}
 
-	_, err := sb.WriteString(foundStdValue)
+	dst = appendSpaces(dst, skippedSpaces)
+	dst = append(dst, value[newOffset:end]...)
+	return dst, end, nil
+}
+
+// clockDigits returns the value of the digits appended by appendFlexibleClockDigits.
+func clockDigits(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+		}
+	}
+	return n
+}
+
+func appendNextNonSpaceValue(dst []byte, value string, offset int, max int, layoutElem string) ([]byte, int, error) {
+	nextValOffset, skippedSpaces, val, err := nextNonSpaceValue(value, offset, max, layoutElem)
 	if err != nil {
-		return valueOffset, err
+		return dst, offset, err
+	}
+
+	dst = appendSpaces(dst, skippedSpaces)
+	dst = append(dst, val...)
+	return dst, nextValOffset, nil
+}
+
+// appendLayoutValue appends the stdTab counterpart of the value matched by the lookup tables,
+// returning the new value offset, and the index of the matched value.
+func appendLayoutValue(dst []byte, layoutElem string, stdTab []string, valueOffset int, value string, opts *Options, trie *nameTrie, lookupTabs ...[]string) ([]byte, int, int, error) {
+	newOffset, skippedSpaces, index, matched, ambiguous, match := lookupWord(valueOffset, value, layoutElem, trie, opts, lookupTabs...)
+	if index < 0 {
+		return dst, valueOffset, index, newLayoutMismatchError(layoutElem, value)
+	}
+
+	if ambiguous && (opts.NarrowNames || match != exactWord) {
+		return dst, valueOffset, index, newAmbiguousValueError(layoutElem, value, matched)
 	}
 
+	dst = appendSpaces(dst, skippedSpaces)
+	dst = append(dst, stdTab[index]...)
+
 	newOffset += len(matched)
-	return newOffset, nil
+	return dst, newOffset, index, nil
+}
+
+// appendSpaces appends n spaces to dst.
+func appendSpaces(dst []byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
+
+// insertString inserts s into dst at the offset position.
+func insertString(dst []byte, offset int, s string) []byte {
+	dst = append(dst, s...)
+	copy(dst[offset+len(s):], dst[offset:len(dst)-len(s)])
+	copy(dst[offset:], s)
+	return dst
 }
 
 func nextNonSpaceValue(value string, offset int, max int, layoutElem string) (newOffset, skippedSpaces int, foundVal string, err error) {
@@ -403,49 +1026,144 @@ func nextNonSpaceValue(value string, offset int, max int, layoutElem string) (ne
 		return offset, skippedSpaces, "", newLayoutMismatchError(layoutElem, value)
 	}
 
-	var sb strings.Builder
-	sb.Grow(len(value) - newOffset)
-	for newOffset < len(value) {
-		if !unicode.IsSpace(rune(value[newOffset])) {
-			sb.WriteByte(value[newOffset])
-			newOffset++
-		} else {
-			return newOffset, skippedSpaces, sb.String(), nil
+	start := newOffset
+	for newOffset < len(value) && newOffset-start < max && !unicode.IsSpace(rune(value[newOffset])) {
+		newOffset++
+	}
+
+	return newOffset, skippedSpaces, value[start:newOffset], nil
+}
+
+// lookup finds the longest value of the lookup tables matching the val content starting
+// at the offset position, and returns its index, or -1 if none matches. All lookup tables
+// must be sorted in the same order. If the longest match is shared by more than one index,
+// the first one is returned, and ambiguous is set to true.
+func lookup(offset int, val string, lookupTabs ...[]string) (newOffset, skippedSpaces int, index int, matched string, ambiguous bool) {
+	return lookupNames(offset, val, nil, nil, lookupTabs...)
+}
+
+// lookupNames is like lookup, but matches the lookup tables in a single pass using their
+// trie, if not nil, instead of comparing all their values. The names are matched as the
+// options set, if not nil, in which case the trie is not used.
+func lookupNames(offset int, val string, trie *nameTrie, opts *Options, lookupTabs ...[]string) (newOffset, skippedSpaces int, index int, matched string, ambiguous bool) {
+	index = -1
+	if opts != nil && opts.matchesNames() {
+		newOffset, skippedSpaces = skipNamesSpaces(val, offset, opts.Spaces)
+		trie = nil
+	} else {
+		newOffset, skippedSpaces = skipLeadingSpace(val, offset)
+	}
+
+	if newOffset >= len(val) {
+		return newOffset, skippedSpaces, index, val, false
+	}
+
+	if trie != nil {
+		index, n, ambiguous := trie.match(val, newOffset)
+		if index < 0 {
+			return newOffset, skippedSpaces, index, "", false
 		}
+		return newOffset, skippedSpaces, index, val[newOffset : newOffset+n], ambiguous
+	}
 
-		if sb.Len() == max {
-			return newOffset, skippedSpaces, sb.String(), nil
+	for _, lookupTab := range lookupTabs {
+		for i, v := range lookupTab {
+			// Already matched a more specific/longer value
+			if index >= 0 && len(v) < len(matched) && (opts == nil || !opts.matchesNames()) {
+				continue
+			}
+
+			n := matchName(val[newOffset:], v, opts)
+			if n < 0 || (index >= 0 && n < len(matched)) {
+				continue
+			}
+
+			if index >= 0 && n == len(matched) {
+				ambiguous = ambiguous || (v != "" && index != i)
+				continue
+			}
+
+			index = i
+			matched = val[newOffset : newOffset+n]
+			ambiguous = false
 		}
 	}
 
-	return newOffset, skippedSpaces, sb.String(), nil
+	return newOffset, skippedSpaces, index, matched, ambiguous
 }
 
-func lookup(lookupTab []string, offset int, val string, stdTab []string) (newOffset, skippedSpaces int, stdValue string, matched string) {
-	newOffset, skippedSpaces = skipLeadingSpace(val, offset)
-	if newOffset >= len(val) {
-		return newOffset, skippedSpaces, "", val
+// matchName returns the length of the value prefix matching the name, or -1 if it does not
+// match. The names are matched ignoring case, or as the options set, if not nil.
+func matchName(value string, name string, opts *Options) int {
+	if opts == nil || !opts.matchesNames() {
+		if len(value) < len(name) || !strings.EqualFold(value[:len(name)], name) {
+			return -1
+		}
+		return len(name)
 	}
 
-	for i, v := range lookupTab {
-		// Already matched a more specific/longer value
-		if stdValue != "" && len(v) <= len(matched) {
-			continue
+	i := 0
+	for j := 0; j < len(name); {
+		// the abbreviations trailing period is optional
+		if opts.AbbreviationPeriods && j == len(name)-1 && name[j] == '.' && (i == len(value) || value[i] != '.') {
+			return i
 		}
 
-		end := newOffset + len(v)
-		if end > len(val) {
+		r, size := utf8.DecodeRuneInString(name[j:])
+		if opts.Spaces == LenientSpaces && unicode.IsSpace(r) {
+			// a run of spaces matches any run of spaces
+			spaces := spacesPrefixLen(value[i:])
+			if spaces == 0 {
+				return -1
+			}
+			i += spaces
+			j += spacesPrefixLen(name[j:])
 			continue
 		}
 
-		candidate := val[newOffset:end]
-		if len(candidate) == len(v) && strings.EqualFold(candidate, v) {
-			stdValue = stdTab[i]
-			matched = candidate
+		if i == len(value) {
+			return -1
 		}
+
+		vr, vsize := utf8.DecodeRuneInString(value[i:])
+		if vr != r && (opts.CaseSensitive || foldRune(vr) != foldRune(r)) {
+			return -1
+		}
+		i += vsize
+		j += size
 	}
 
-	return newOffset, skippedSpaces, stdValue, matched
+	return i
+}
+
+// skipNamesSpaces skips the spaces preceding the names, as the spaces mode sets. The strict
+// spaces are checked by parseNative, as translate relies on skipping them to stay in sync
+// with the layout.
+func skipNamesSpaces(s string, i int, mode SpacesMode) (newI int, skippedBytes int) {
+	if mode == LenientSpaces {
+		n := spacesPrefixLen(s[i:])
+		return i + n, n
+	}
+	return skipLeadingSpace(s, i)
+}
+
+// normalizeSpaces replaces the runs of Unicode white space characters of the value by a
+// single space, and removes the leading and trailing ones.
+func normalizeSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// spacesPrefixLen returns the length of the Unicode white space characters prefixing s.
+func spacesPrefixLen(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		n += size
+	}
+	return n
 }
 
 func skipLeadingSpace(s string, i int) (newI int, skippedBytes int) {
@@ -489,6 +1207,34 @@ func newLayoutMismatchError(elem, value string) error {
 	}
 }
 
+// ErrAmbiguousValue indicates that a provided value matches more than one name of its
+// layout element counterpart, e.g. the narrow day name "T" for Tuesday and Thursday.
+type ErrAmbiguousValue struct {
+	Value      string
+	LayoutElem string
+	Matched    string
+}
+
+func (a *ErrAmbiguousValue) Error() string {
+	return fmt.Sprintf(`value "%s" is ambiguous for the layout element "%s", "%s" matches more than one name`, a.Value, a.LayoutElem, a.Matched)
+}
+
+func (a *ErrAmbiguousValue) Is(err error) bool {
+	var target *ErrAmbiguousValue
+	if ok := errors.As(err, &target); ok {
+		return a.Value == target.Value && a.LayoutElem == target.LayoutElem && a.Matched == target.Matched
+	}
+	return false
+}
+
+func newAmbiguousValueError(elem, value, matched string) error {
+	return &ErrAmbiguousValue{
+		LayoutElem: elem,
+		Value:      value,
+		Matched:    matched,
+	}
+}
+
 // ErrUnsupportedLayoutElem indicates that a provided layout element is not supported by
 // the given locale/language.
 type ErrUnsupportedLayoutElem struct {
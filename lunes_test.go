@@ -964,7 +964,7 @@ func TestAllLocalesReplacements(t *testing.T) {
 func TestUnsupportedLayoutElements(t *testing.T) {
 	locale := genericLocale{
 		lang: LocaleEn,
-		table: [5][]string{
+		table: [localeTableSize][]string{
 			{}, {}, {}, {}, {},
 		},
 	}
@@ -1060,6 +1060,13 @@ func TestTranslateFlexibleClock(t *testing.T) {
 			lang:           LocaleEsES,
 			wantTranslated: "Monday Oct 27 1988 9:05:7 pm",
 		},
+		{
+			name:           "SingleDigit24Hour",
+			layout:         "Monday Jan _2 2006 15:04:05",
+			localized:      "lunes oct 27 1988 8:53:29",
+			lang:           LocaleEsES,
+			wantTranslated: "Monday Oct 27 1988 8:53:29",
+		},
 		{
 			name:           "FixedWidth030405Layout",
 			layout:         "Monday Jan _2 2006 03:04:05 pm",
@@ -1168,6 +1175,308 @@ func TestTranslateFlexibleClock(t *testing.T) {
 	}
 }
 
+func TestTranslateStandAloneNames(t *testing.T) {
+	table, _ := getTable(LocalePl)
+	table[standAloneLongMonthNamesField] = []string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"}
+	table[standAloneShortDayNamesField] = []string{"niedz.", "pon.", "wt.", "śr.", "czw.", "pt.", "sob."}
+	locale := &genericLocale{lang: LocalePl, table: table}
+
+	tests := []struct {
+		name   string
+		layout string
+		value  string
+		want   string
+	}{
+		{
+			name:   "StandAloneLongMonth",
+			layout: "January 2006",
+			value:  "Październik 2024",
+			want:   "October 2024",
+		},
+		{
+			name:   "FormatLongMonth",
+			layout: "2 January 2006",
+			value:  "27 października 2024",
+			want:   "27 October 2024",
+		},
+		{
+			name:   "StandAloneShortDay",
+			layout: "Mon, 2 Jan 2006",
+			value:  "czw., 27 paź 2024",
+			want:   "Thu, 27 Oct 2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateWithLocale(tt.layout, tt.value, locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected value %q, got: %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("OnlyStandAloneNames", func(t *testing.T) {
+		table[longMonthNamesField] = []string{}
+		locale := &genericLocale{lang: LocalePl, table: table}
+
+		got, err := TranslateWithLocale("January 2006", "październik 2024", locale)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		if got != "October 2024" {
+			t.Errorf("expected value \"October 2024\", got: %q", got)
+		}
+	})
+}
+
+func TestParseStandAloneNames(t *testing.T) {
+	tests := []struct {
+		lang   string
+		layout string
+		value  string
+		want   time.Time
+	}{
+		{lang: LocalePl, layout: "January 2006", value: "Październik 2024", want: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{lang: LocalePl, layout: "2 January 2006", value: "27 października 2024", want: time.Date(2024, time.October, 27, 0, 0, 0, 0, time.UTC)},
+		{lang: LocaleRu, layout: "January 2006", value: "Октябрь 2024", want: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{lang: LocaleRu, layout: "2 January 2006", value: "27 октября 2024", want: time.Date(2024, time.October, 27, 0, 0, 0, 0, time.UTC)},
+		{lang: LocaleCs, layout: "January 2006", value: "říjen 2024", want: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{lang: LocaleCs, layout: "2. January 2006", value: "27. října 2024", want: time.Date(2024, time.October, 27, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.value, func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, standAloneLongMonthNamesField)
+
+			got, err := Parse(tt.layout, tt.value, tt.lang)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+var esErasTestFields = map[int][]string{
+	longEraNamesField:  {"antes de Cristo", "después de Cristo"},
+	shortEraNamesField: {"a. C.", "d. C."},
+}
+
+func TestParseEras(t *testing.T) {
+	es := newTestLocale(LocaleEs, esErasTestFields)
+	zh := newTestLocale(LocaleZh, map[int][]string{longEraNamesField: {"公元前", "公元"}, shortEraNamesField: {"公元前", "公元"}})
+
+	tests := []struct {
+		name           string
+		layout         string
+		value          string
+		locale         Locale
+		wantTranslated string
+		want           time.Time
+	}{
+		{
+			name:           "ShortEraAD",
+			layout:         "2 Jan 2006 AD",
+			value:          "27 oct 1988 d. C.",
+			locale:         es,
+			wantTranslated: "27 Oct 1988 AD",
+			want:           time.Date(1988, time.October, 27, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "ShortEraBC",
+			layout:         "2 January 2006 AD",
+			value:          "15 marzo 44 a. C.",
+			locale:         es,
+			wantTranslated: "15 March 0044 BC",
+			want:           time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "LongEraBC",
+			layout:         "2006 Anno Domini",
+			value:          "1 antes de Cristo",
+			locale:         es,
+			wantTranslated: "0001 Before Christ",
+			want:           time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "EraPrefix",
+			layout:         "AD 2006年",
+			value:          "公元前 44年",
+			locale:         zh,
+			wantTranslated: "BC 0044年",
+			want:           time.Date(-43, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "LongestEraMatch",
+			layout:         "AD2006年01月02日",
+			value:          "公元2024年10月16日",
+			locale:         zh,
+			wantTranslated: "AD2024年10月16日",
+			want:           time.Date(2024, time.October, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "BCLeapDay",
+			layout:         "2 Jan 2006 AD",
+			value:          "29 feb 5 a. C.",
+			locale:         es,
+			wantTranslated: "29 Feb 0005 BC",
+			want:           time.Date(-4, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated, err := TranslateWithLocale(tt.layout, tt.value, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if translated != tt.wantTranslated {
+				t.Errorf("expected translated value %q, got: %q", tt.wantTranslated, translated)
+			}
+
+			got, err := ParseWithLocale(tt.layout, tt.value, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+
+			got, err = ParseInLocationWithLocale(tt.layout, tt.value, defaultLocation, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			want := time.Date(tt.want.Year(), tt.want.Month(), tt.want.Day(), 0, 0, 0, 0, defaultLocation)
+			if !got.Equal(want) {
+				t.Errorf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+
+	t.Run("InvalidBCLeapDay", func(t *testing.T) {
+		_, err := ParseWithLocale("2 Jan 2006 AD", "29 feb 4 a. C.", es)
+		if err == nil {
+			t.Error("expected error, got: nil")
+		}
+	})
+
+	t.Run("UnsupportedEras", func(t *testing.T) {
+		locale := newTestLocale(LocaleEn, map[int][]string{longEraNamesField: {"Before Christ", "Anno Domini"}, shortEraNamesField: nil})
+		_, err := TranslateWithLocale("2006 AD", "2024 AD", locale)
+		expectedErr := newUnsupportedLayoutElemError("AD", locale)
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+		}
+	})
+
+	t.Run("LiteralEras", func(t *testing.T) {
+		// locales with no era names match the era elements as literals
+		locale, _ := NewDefaultLocale(LocaleEn)
+		got, err := ParseWithLocale("2006 AD", "0024 AD", &customLocale{locale})
+		want := time.Date(24, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("expected: %v, got: %v ('%v')", want, got, err)
+		}
+	})
+
+	t.Run("YearMismatch", func(t *testing.T) {
+		value := "oct d. C."
+		_, err := TranslateWithLocale("Jan 2006 AD", value, es)
+		expectedErr := newLayoutMismatchError("2006", value)
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("expected error: '%v', got: '%v'", expectedErr, err)
+		}
+	})
+}
+
+// customLocale hides the optional interfaces implemented by the wrapped Locale.
+type customLocale struct {
+	Locale
+}
+
+func TestEraLayoutWords(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+
+	tests := []struct {
+		layout string
+		value  string
+		want   time.Time
+	}{
+		{layout: "2006-01-02 15:04 ADT", value: "2024-10-16 11:53 ADT", want: time.Date(2024, time.October, 16, 11, 53, 0, 0, time.UTC)},
+		{layout: "Jan 2 2006 MADRID", value: "oct 16 2024 MADRID", want: time.Date(2024, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{layout: "Jan 2 2006 Anno Dominis", value: "oct 16 2024 Anno Dominis", want: time.Date(2024, time.October, 16, 0, 0, 0, 0, time.UTC)},
+	}
+
+	// the era elements are whole words, so the literals containing them are kept
+	for _, tt := range tests {
+		for _, locale := range []Locale{es, esEras} {
+			t.Run(locale.Language()+" "+tt.layout, func(t *testing.T) {
+				got, err := ParseWithLocale(tt.layout, tt.value, locale)
+				if err != nil {
+					t.Fatalf("expected no error, got: '%v'", err)
+				}
+
+				if !got.Equal(tt.want) {
+					t.Errorf("expected: %v, got: %v", tt.want, got)
+				}
+
+				translated, err := TranslateWithLocale(tt.layout, tt.value, locale)
+				want := tt.want.Format(tt.layout)
+				if err != nil || translated != want {
+					t.Errorf("expected: '%s', got: '%s' ('%v')", want, translated, err)
+				}
+
+				formatted, err := FormatWithLocale(tt.want, tt.layout, locale)
+				if err != nil || formatted != tt.value {
+					t.Errorf("expected: '%s', got: '%s' ('%v')", tt.value, formatted, err)
+				}
+			})
+		}
+	}
+
+	t.Run("YearDigits", func(t *testing.T) {
+		// the years are not read as era years, which might have less than 4 digits
+		if _, err := ParseWithLocale("2 Jan 2006 ADT", "16 oct 24 ADT", esEras); err == nil {
+			t.Error("expected error, got: nil")
+		}
+	})
+}
+
+func TestParseErasLocales(t *testing.T) {
+	skipUngeneratedFields(t, LocaleEs, longEraNamesField, shortEraNamesField)
+
+	tests := []struct {
+		layout string
+		value  string
+		want   time.Time
+	}{
+		{layout: "Jan 2 2006 AD", value: "oct 2 2024 d. C.", want: time.Date(2024, time.October, 2, 0, 0, 0, 0, time.UTC)},
+		{layout: "2 January 2006 Anno Domini", value: "15 marzo 44 antes de Cristo", want: time.Date(-43, time.March, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.layout, tt.value, LocaleEs)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v ('%v')", tt.want, got, err)
+			}
+		})
+	}
+}
+
 func TestTranslateWithUnsupportedLocale(t *testing.T) {
 	lang := "ann"
 	format := "Mon January 03:04:05PM"
@@ -0,0 +1,902 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// chunkKind is the kind of the layout chunks read by parseNative, following the layout
+// elements of the time package.
+type chunkKind uint8
+
+const (
+	literalChunk chunkKind = iota
+	longMonthChunk
+	monthChunk
+	numMonthChunk
+	zeroMonthChunk
+	longWeekDayChunk
+	weekDayChunk
+	dayChunk
+	underDayChunk
+	zeroDayChunk
+	underYearDayChunk
+	zeroYearDayChunk
+	hourChunk
+	hour12Chunk
+	zeroHour12Chunk
+	minuteChunk
+	zeroMinuteChunk
+	secondChunk
+	zeroSecondChunk
+	longYearChunk
+	yearChunk
+	pmChunk
+	lowerPMChunk
+	timeZoneChunk
+	// the numeric time zones chunks, ISO 8601 ones accepting Z for UTC
+	numTZChunk
+	numShortTZChunk
+	numColonTZChunk
+	numSecondsTZChunk
+	numColonSecondsTZChunk
+	isoTZChunk
+	isoShortTZChunk
+	isoColonTZChunk
+	isoSecondsTZChunk
+	isoColonSecondsTZChunk
+	fracSecond0Chunk
+	fracSecond9Chunk
+	// eraChunk is the lunes specific AD and Anno Domini layout elements.
+	eraChunk
+)
+
+// numTZChunks holds the numeric time zones layout elements, from the longest.
+var numTZChunks = []struct {
+	name string
+	kind chunkKind
+}{
+	{name: "-070000", kind: numSecondsTZChunk},
+	{name: "-07:00:00", kind: numColonSecondsTZChunk},
+	{name: "-0700", kind: numTZChunk},
+	{name: "-07:00", kind: numColonTZChunk},
+	{name: "-07", kind: numShortTZChunk},
+	{name: "Z070000", kind: isoSecondsTZChunk},
+	{name: "Z07:00:00", kind: isoColonSecondsTZChunk},
+	{name: "Z0700", kind: isoTZChunk},
+	{name: "Z07:00", kind: isoColonTZChunk},
+	{name: "Z07", kind: isoShortTZChunk},
+}
+
+// stdChunkAt returns the time package layout element starting at the layout offset, and
+// its length, or literalChunk if none does, as the time package finds them.
+func stdChunkAt(layout string, i int) (chunkKind, int) {
+	switch c := layout[i]; c {
+	case 'J': // January, Jan
+		if strings.HasPrefix(layout[i:], "January") {
+			return longMonthChunk, 7
+		}
+		if strings.HasPrefix(layout[i:], "Jan") && !startsWithLowerCase(layout[i+3:]) {
+			return monthChunk, 3
+		}
+	case 'M': // Monday, Mon, MST
+		if strings.HasPrefix(layout[i:], "Monday") {
+			return longWeekDayChunk, 6
+		}
+		if strings.HasPrefix(layout[i:], "Mon") && !startsWithLowerCase(layout[i+3:]) {
+			return weekDayChunk, 3
+		}
+		if strings.HasPrefix(layout[i:], "MST") {
+			return timeZoneChunk, 3
+		}
+	case '0': // 01, 02, 03, 04, 05, 06, 002
+		if i+1 < len(layout) && '1' <= layout[i+1] && layout[i+1] <= '6' {
+			return [...]chunkKind{zeroMonthChunk, zeroDayChunk, zeroHour12Chunk, zeroMinuteChunk, zeroSecondChunk, yearChunk}[layout[i+1]-'1'], 2
+		}
+		if strings.HasPrefix(layout[i:], "002") {
+			return zeroYearDayChunk, 3
+		}
+	case '1': // 15, 1
+		if strings.HasPrefix(layout[i:], "15") {
+			return hourChunk, 2
+		}
+		return numMonthChunk, 1
+	case '2': // 2006, 2
+		if strings.HasPrefix(layout[i:], "2006") {
+			return longYearChunk, 4
+		}
+		return dayChunk, 1
+	case '_': // _2, __2, while _2006 is a literal _, followed by the long year
+		if strings.HasPrefix(layout[i:], "_2") && !strings.HasPrefix(layout[i:], "_2006") {
+			return underDayChunk, 2
+		}
+		if strings.HasPrefix(layout[i:], "__2") {
+			return underYearDayChunk, 3
+		}
+	case '3':
+		return hour12Chunk, 1
+	case '4':
+		return minuteChunk, 1
+	case '5':
+		return secondChunk, 1
+	case 'P': // PM
+		if strings.HasPrefix(layout[i:], "PM") {
+			return pmChunk, 2
+		}
+	case 'p': // pm
+		if strings.HasPrefix(layout[i:], "pm") {
+			return lowerPMChunk, 2
+		}
+	case '-', 'Z': // -070000, -07:00:00, -0700, -07:00, -07, and their Z counterparts
+		for _, tz := range numTZChunks {
+			if strings.HasPrefix(layout[i:], tz.name) {
+				return tz.kind, len(tz.name)
+			}
+		}
+	case '.', ',': // ,000, or .000, or ,999, or .999
+		if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+			j := i + 1
+			for j < len(layout) && layout[j] == layout[i+1] {
+				j++
+			}
+
+			// the string of digits must end here
+			if !isDigitAt(layout, j) {
+				if layout[i+1] == '9' {
+					return fracSecond9Chunk, j - i
+				}
+				return fracSecond0Chunk, j - i
+			}
+		}
+	}
+
+	return literalChunk, 1
+}
+
+// nativeParser holds the fields read by parseNative.
+type nativeParser struct {
+	year, month, day, yday int
+	hour, min, sec, nsec   int
+	// hour12 holds the 12-hour clock value, used to resolve flexible day periods
+	hour12       int
+	amSet, pmSet bool
+	period       pendingDayPeriod
+	zone         *time.Location
+	zoneOffset   int
+	// eraYears holds the number of era years read, and state the era and localized
+	// time zone, applied as parse does.
+	eraYears int
+	state    parseState
+	// diagnose reads the layout elements parseNative leaves to translate as the time
+	// package does, so the failures are found past them.
+	diagnose bool
+	// failed indicates that the value did not match the layout element at failedAt, of
+	// failedLen bytes, or the trailing value if it is the layout length, and failedValue
+	// holds the value from the mismatch. The day and period elements are kept to locate
+	// their failures once the time is built.
+	failed              bool
+	failedAt, failedLen int
+	failedValue         string
+	dayAt, dayLen       int
+	dayValue            string
+	periodAt, periodLen int
+	periodValue         string
+	// recordNames records the names read in names, with their offsets in value, the
+	// value read, and language, the layout locale one.
+	recordNames bool
+	value       string
+	language    string
+	names       []NameMatch
+}
+
+func newNativeParser() nativeParser {
+	p := nativeParser{month: -1, day: -1, yday: -1, hour12: -1, zoneOffset: -1, dayAt: -1, periodAt: -1}
+	p.period.offset = -1
+	p.state.yearOffset = -1
+	return p
+}
+
+// parseNative parses the localized value in a single pass, reading the fields of the layout
+// elements, and building the time from them, without translating the value to English. It
+// follows the rules of the time package parsing functions, reporting whether the value was
+// parsed. Values it does not parse are parsed again by translating them, which returns the
+// same errors as the time package, so it does not build any.
+func parseNative(layout string, elems []layoutElem, value string, location *time.Location, locale Locale, opts *Options) (time.Time, bool) {
+	p := newNativeParser()
+	if !p.read(layout, elems, transliterateDigits(value, locale), locale, opts) {
+		return time.Time{}, false
+	}
+
+	return p.time(location)
+}
+
+// read reads the fields of the layout elements from the value, recording where the value
+// did not match the layout.
+func (p *nativeParser) read(layout string, elems []layoutElem, value string, locale Locale, opts *Options) bool {
+	eraLayout := isEraLayout(layout, locale)
+	p.value = value
+	if p.recordNames {
+		p.language = locale.Language()
+	}
+	if opts.Spaces == LenientSpaces && !strings.HasPrefix(layout, " ") {
+		value = value[spacesPrefixLen(value):]
+	}
+
+	var found layoutElem
+	for i := 0; i < len(layout); {
+		kind, n := stdChunkAt(layout, i)
+
+		// the lunes specific layout elements, found as translate does
+		switch layout[i] {
+		case 'A':
+			if elem := eraLayoutElemAt(layout, i); eraLayout && elem != "" {
+				kind, n = eraChunk, len(elem)
+			}
+		case 'P', 'p':
+			// translate also matches the day periods for PM and pm case variants
+			if kind == literalChunk && i+1 < len(layout) && (layout[i+1] == 'M' || layout[i+1] == 'm') {
+				if !p.diagnose {
+					return false
+				}
+				kind, n = pmChunk, 2
+			}
+		}
+
+		if kind == literalChunk {
+			rest, ok := skipLiteral(layout[i], value, opts.Spaces)
+			if !ok {
+				return p.fail(i, 1, value)
+			}
+			value = rest
+
+			// a layout space matches any number of spaces, once, unless they are strict
+			for i++; opts.Spaces != StrictSpaces && layout[i-1] == ' ' && i < len(layout) && layout[i] == ' '; i++ {
+			}
+			continue
+		}
+
+		var elem *layoutElem
+		switch kind {
+		case longMonthChunk, monthChunk, longWeekDayChunk, weekDayChunk, pmChunk, lowerPMChunk, eraChunk, timeZoneChunk:
+			elem = &found
+			if elems != nil {
+				elem = &elems[i]
+			} else {
+				setLayoutElem(&found, layout, i, locale, opts, eraLayout)
+			}
+
+			if elem.err != nil {
+				return false
+			}
+		}
+
+		// the time zones abbreviations are left to the time package
+		if kind == timeZoneChunk && elem.kind != timeZoneLayoutElem && !p.diagnose {
+			return false
+		}
+
+		switch kind {
+		case dayChunk, underDayChunk, zeroDayChunk, underYearDayChunk, zeroYearDayChunk:
+			p.dayAt, p.dayLen, p.dayValue = i, n, value
+		case pmChunk, lowerPMChunk:
+			p.periodAt, p.periodLen, p.periodValue = i, n, value
+		}
+
+		rest, ok := p.readChunk(kind, n, layout, i, elem, value, locale, opts, eraLayout)
+		if !ok {
+			return p.fail(i, n, value)
+		}
+		value = rest
+		i += n
+	}
+
+	if opts.Spaces == LenientSpaces && spacesPrefixLen(value) == len(value) {
+		value = ""
+	}
+
+	if len(value) > 0 && !opts.IgnoreTrailingInput {
+		return p.fail(len(layout), 0, value)
+	}
+
+	// the era years are read by translate for all the 2006 elements not preceded by _
+	if eraLayout && p.eraYears != strings.Count(layout, "2006")-strings.Count(layout, "_2006") {
+		return false
+	}
+
+	return true
+}
+
+// nativeMismatchError returns the ErrLayoutMismatch error of the layout element the value does
+// not match, or the *time.ParseError error of its extra text, if parseNative finds any.
+func nativeMismatchError(layout string, elems []layoutElem, value string, locale Locale, opts *Options) error {
+	p := newNativeParser()
+	if p.read(layout, elems, transliterateDigits(value, locale), locale, opts) {
+		p.time(nil)
+	}
+
+	switch {
+	case !p.failed || p.failedAt < 0:
+		return nil
+	case p.failedAt == len(layout):
+		return &time.ParseError{Layout: layout, Value: value, ValueElem: p.failedValue, Message: ": extra text: " + strconv.Quote(p.failedValue)}
+	}
+	return newLayoutMismatchError(layout[p.failedAt:p.failedAt+p.failedLen], value)
+}
+
+// fail records that the value does not match the layout element at the layout offset.
+func (p *nativeParser) fail(i, n int, value string) bool {
+	p.failed, p.failedAt, p.failedLen, p.failedValue = true, i, n, value
+	return false
+}
+
+// skipLiteral skips the layout literal byte on the value, as the time package does, where
+// a space matches any number of spaces, or as the spaces mode sets.
+func skipLiteral(c byte, value string, mode SpacesMode) (string, bool) {
+	if c == ' ' {
+		switch mode {
+		case StrictSpaces:
+			if len(value) == 0 || value[0] != ' ' {
+				return value, false
+			}
+			return value[1:], true
+		case LenientSpaces:
+			n := spacesPrefixLen(value)
+			if len(value) > 0 && n == 0 {
+				return value, false
+			}
+			return value[n:], true
+		}
+
+		if len(value) > 0 && value[0] != ' ' {
+			return value, false
+		}
+		return strings.TrimLeft(value, " "), true
+	}
+
+	if len(value) == 0 || value[0] != c {
+		return value, false
+	}
+	return value[1:], true
+}
+
+// readChunk reads the layout chunk field from the value, returning the remaining value.
+func (p *nativeParser) readChunk(kind chunkKind, n int, layout string, i int, elem *layoutElem, value string, locale Locale, opts *Options, eraLayout bool) (string, bool) {
+	var ok bool
+	switch kind {
+	case yearChunk:
+		if len(value) < 2 || !isDigitAt(value, 0) || !isDigitAt(value, 1) {
+			return value, false
+		}
+		p.year = int(value[0]-'0')*10 + int(value[1]-'0')
+		if p.year >= 69 {
+			p.year += 1900
+		} else {
+			p.year += 2000
+		}
+		return value[2:], true
+	case longYearChunk:
+		// era years might have less than 4 digits, and before the common era, the year
+		// validating the days is one with the same leap year rules
+		if eraLayout && (i == 0 || layout[i-1] != '_') {
+			digits := 0
+			for digits < len(value) && isDigitAt(value, digits) {
+				digits++
+			}
+			if digits == 0 || digits > 4 {
+				return value, false
+			}
+
+			p.state.year, _ = atoiDigits(value[:digits])
+			p.state.yearOffset = 0
+			p.year = p.state.year
+			p.eraYears++
+			return value[digits:], true
+		}
+
+		if len(value) < 4 {
+			return value, false
+		}
+		if p.year, ok = atoiDigits(value[:4]); !ok {
+			return value, false
+		}
+		return value[4:], true
+	case longMonthChunk, monthChunk:
+		var index int
+		if index, value, ok = p.lookupName(elem, value, opts); !ok {
+			return value, false
+		}
+		p.month = index + 1
+		if kind == monthChunk {
+			value = skipAbbreviationPeriod(layout, i+n, value, opts)
+		}
+		return value, true
+	case longWeekDayChunk, weekDayChunk:
+		// week days are only checked
+		if _, value, ok = p.lookupName(elem, value, opts); ok && kind == weekDayChunk {
+			value = skipAbbreviationPeriod(layout, i+n, value, opts)
+		}
+		return value, ok
+	case numMonthChunk, zeroMonthChunk:
+		if p.month, value, ok = getnum(value, kind == zeroMonthChunk); !ok || p.month <= 0 || p.month > 12 {
+			return value, false
+		}
+		return value, true
+	case dayChunk, underDayChunk, zeroDayChunk:
+		if kind == underDayChunk && len(value) > 0 && value[0] == ' ' {
+			value = value[1:]
+		}
+		p.day, value, ok = getnum(value, kind == zeroDayChunk)
+		return value, ok
+	case underYearDayChunk, zeroYearDayChunk:
+		for j := 0; j < 2; j++ {
+			if kind == underYearDayChunk && len(value) > 0 && value[0] == ' ' {
+				value = value[1:]
+			}
+		}
+		p.yday, value, ok = getnum3(value, kind == zeroYearDayChunk)
+		return value, ok
+	case hourChunk:
+		if p.hour, value, ok = getnum(value, false); !ok || p.hour >= 24 {
+			return value, false
+		}
+		return value, true
+	case hour12Chunk, zeroHour12Chunk:
+		if p.hour, value, ok = getnum(value, kind == zeroHour12Chunk); !ok || p.hour > 12 {
+			return value, false
+		}
+		p.hour12 = p.hour
+		return value, true
+	case minuteChunk, zeroMinuteChunk:
+		if p.min, value, ok = getnum(value, kind == zeroMinuteChunk); !ok || p.min >= 60 {
+			return value, false
+		}
+		return value, true
+	case secondChunk, zeroSecondChunk:
+		if p.sec, value, ok = getnum(value, kind == zeroSecondChunk); !ok || p.sec >= 60 {
+			return value, false
+		}
+
+		// fractional seconds are read even if the layout has none
+		if len(value) >= 2 && isCommaOrPeriod(value[0]) && isDigitAt(value, 1) {
+			if next := nextStdChunk(layout, i+n); next == fracSecond0Chunk || next == fracSecond9Chunk {
+				return value, true
+			}
+
+			digits := 2
+			for digits < len(value) && isDigitAt(value, digits) {
+				digits++
+			}
+			if p.nsec, ok = parseNanoseconds(value, digits); !ok {
+				return value, false
+			}
+			value = value[digits:]
+		}
+		return value, true
+	case fracSecond0Chunk:
+		if len(value) < n {
+			return value, false
+		}
+		if p.nsec, ok = parseNanoseconds(value, n); !ok {
+			return value, false
+		}
+		return value[n:], true
+	case fracSecond9Chunk:
+		if len(value) < 2 || !isCommaOrPeriod(value[0]) || !isDigitAt(value, 1) {
+			// fractional second omitted
+			return value, true
+		}
+
+		digits := 1
+		for digits < len(value) && isDigitAt(value, digits) {
+			digits++
+		}
+		if p.nsec, ok = parseNanoseconds(value, digits); !ok {
+			return value, false
+		}
+		return value[digits:], true
+	case pmChunk, lowerPMChunk:
+		return p.readDayPeriod(elem, value, opts)
+	case eraChunk:
+		var index int
+		if index, value, ok = p.lookupName(elem, value, opts); !ok {
+			return value, false
+		}
+		p.state.bc = index == 0
+		return value, true
+	case timeZoneChunk:
+		// the time zones names are only matched when enabled, as the time package
+		// resolves the abbreviations using the local time zones
+		if elem.kind != timeZoneLayoutElem {
+			return skipTimeZoneAbbreviation(value)
+		}
+
+		if startsWithSpace(value) {
+			return value, false
+		}
+
+		n, location, err := matchTimeZone(value, 0, locale)
+		if err != nil || location == nil {
+			return value, false
+		}
+		p.zone = time.UTC
+		p.state.location = location
+		return value[n:], true
+	case isoTZChunk, isoShortTZChunk, isoColonTZChunk, isoSecondsTZChunk, isoColonSecondsTZChunk:
+		if len(value) >= 1 && value[0] == 'Z' {
+			p.zone = time.UTC
+			return value[1:], true
+		}
+		return p.readZoneOffset(kind, value)
+	case numTZChunk, numShortTZChunk, numColonTZChunk, numSecondsTZChunk, numColonSecondsTZChunk:
+		return p.readZoneOffset(kind, value)
+	}
+
+	return value, false
+}
+
+// readDayPeriod reads the AM/PM day periods, or the flexible ones when they match a longer
+// value, as translate does.
+func (p *nativeParser) readDayPeriod(elem *layoutElem, value string, opts *Options) (string, bool) {
+	if opts.Spaces != LenientSpaces && startsWithSpace(value) {
+		return value, false
+	}
+
+	offset, _, stdIndex, stdMatched, ambiguous := lookupNames(0, value, elem.lookupTrie, opts, elem.lookupTab)
+	_, _, index, matched, _ := lookupNames(0, value, elem.trie, opts, elem.lookupTabs()...)
+	if index >= 0 && len(matched) > len(stdMatched) {
+		var rule string
+		if index < len(elem.rules) {
+			rule = elem.rules[index]
+		}
+
+		// the period is resolved once the hour is known
+		p.period = pendingDayPeriod{offset: 0, index: index, rule: rule}
+		p.recordName(elem, value[offset:], matched, false)
+		return value[offset+len(matched):], true
+	}
+
+	// the fallback locales AM/PM names are matched as translate does
+	matchedElem := elem
+	for i := 0; stdIndex < 0 && i < len(elem.fallbacks); i++ {
+		matchedElem = &elem.fallbacks[i]
+		offset, _, stdIndex, stdMatched, ambiguous = lookupNames(0, value, matchedElem.lookupTrie, opts, matchedElem.lookupTab)
+	}
+
+	if stdIndex < 0 || (ambiguous && opts.NarrowNames) {
+		return value, false
+	}
+
+	p.amSet, p.pmSet = stdIndex == 0, stdIndex == 1
+	p.recordName(matchedElem, value[offset:], stdMatched, false)
+	return value[offset+len(stdMatched):], true
+}
+
+// readZoneOffset reads the numeric time zone offset.
+func (p *nativeParser) readZoneOffset(kind chunkKind, value string) (string, bool) {
+	var sign byte
+	var hh, mm, ss string
+	switch kind {
+	case isoColonTZChunk, numColonTZChunk:
+		if len(value) < 6 || value[3] != ':' {
+			return value, false
+		}
+		sign, hh, mm, ss, value = value[0], value[1:3], value[4:6], "00", value[6:]
+	case isoShortTZChunk, numShortTZChunk:
+		if len(value) < 3 {
+			return value, false
+		}
+		sign, hh, mm, ss, value = value[0], value[1:3], "00", "00", value[3:]
+	case isoColonSecondsTZChunk, numColonSecondsTZChunk:
+		if len(value) < 9 || value[3] != ':' || value[6] != ':' {
+			return value, false
+		}
+		sign, hh, mm, ss, value = value[0], value[1:3], value[4:6], value[7:9], value[9:]
+	case isoSecondsTZChunk, numSecondsTZChunk:
+		if len(value) < 7 {
+			return value, false
+		}
+		sign, hh, mm, ss, value = value[0], value[1:3], value[3:5], value[5:7], value[7:]
+	default:
+		if len(value) < 5 {
+			return value, false
+		}
+		sign, hh, mm, ss, value = value[0], value[1:3], value[3:5], "00", value[5:]
+	}
+
+	hr, _, ok1 := getnum(hh, true)
+	min, _, ok2 := getnum(mm, true)
+	sec, _, ok3 := getnum(ss, true)
+	if !ok1 || !ok2 || !ok3 || hr > 24 || min > 60 || sec > 60 {
+		return value, false
+	}
+
+	p.zoneOffset = (hr*60+min)*60 + sec
+	switch sign {
+	case '+':
+	case '-':
+		p.zoneOffset = -p.zoneOffset
+	default:
+		return value, false
+	}
+	return value, true
+}
+
+// time builds the time from the read fields, as the time package parsing functions do.
+func (p *nativeParser) time(location *time.Location) (time.Time, bool) {
+	if p.period.offset >= 0 {
+		period, ok := p.period.resolve(p.hour12)
+		if !ok {
+			p.fail(p.periodAt, p.periodLen, p.periodValue)
+			return time.Time{}, false
+		}
+		p.amSet, p.pmSet = period == 0, period == 1
+	}
+
+	if p.pmSet && p.hour < 12 {
+		p.hour += 12
+	} else if p.amSet && p.hour == 12 {
+		p.hour = 0
+	}
+
+	// before the common era, the days are validated with the proleptic Gregorian year
+	// leap year rules, and the year is set afterward by parseState.apply
+	year := p.year
+	if p.state.bc && p.state.yearOffset >= 0 {
+		year = 2001
+		if isLeap(1 - p.state.year) {
+			year = 2000
+		}
+	}
+
+	month, day := p.month, p.day
+	if p.yday >= 0 {
+		yday := p.yday
+		m, d := 0, 0
+		if isLeap(year) {
+			if yday == 31+29 {
+				m, d = int(time.February), 29
+			} else if yday > 31+29 {
+				yday--
+			}
+		}
+		if yday < 1 || yday > 365 {
+			p.fail(p.dayAt, p.dayLen, p.dayValue)
+			return time.Time{}, false
+		}
+		if m == 0 {
+			m = (yday-1)/31 + 1
+			if daysBefore[m] < yday {
+				m++
+			}
+			d = yday - daysBefore[m-1]
+		}
+		if (month >= 0 && month != m) || (day >= 0 && day != d) {
+			p.fail(p.dayAt, p.dayLen, p.dayValue)
+			return time.Time{}, false
+		}
+		month, day = m, d
+	} else {
+		if month < 0 {
+			month = int(time.January)
+		}
+		if day < 0 {
+			day = 1
+		}
+	}
+
+	if day < 1 || day > daysIn(time.Month(month), year) {
+		p.fail(p.dayAt, p.dayLen, p.dayValue)
+		return time.Time{}, false
+	}
+
+	var t time.Time
+	switch {
+	case p.zone != nil:
+		t = time.Date(year, time.Month(month), day, p.hour, p.min, p.sec, p.nsec, p.zone)
+	case p.zoneOffset != -1:
+		t = time.Date(year, time.Month(month), day, p.hour, p.min, p.sec, p.nsec, time.UTC)
+		t = t.Add(-time.Duration(p.zoneOffset) * time.Second)
+
+		// the local time zone is used if its offset was in effect at the given time
+		local := location
+		if local == nil {
+			local = time.Local
+		}
+		if _, offset := t.In(local).Zone(); offset == p.zoneOffset {
+			t = t.In(local)
+		} else {
+			t = t.In(time.FixedZone("", p.zoneOffset))
+		}
+	default:
+		if location == nil {
+			location = time.UTC
+		}
+		t = time.Date(year, time.Month(month), day, p.hour, p.min, p.sec, p.nsec, location)
+	}
+
+	return p.state.apply(t), true
+}
+
+// lookupName returns the index of the layout element name matching the value start, or the
+// value word, as lookupWord finds it, or the fallback elements names, in order, if none does.
+func (p *nativeParser) lookupName(elem *layoutElem, value string, opts *Options) (int, string, bool) {
+	if opts.Spaces != LenientSpaces && startsWithSpace(value) {
+		return -1, value, false
+	}
+
+	for i := -1; i < len(elem.fallbacks); i++ {
+		e := elem
+		if i >= 0 {
+			e = &elem.fallbacks[i]
+		}
+
+		offset, _, index, matched, ambiguous, match := lookupWord(0, value, elem.name, e.trie, opts, e.lookupTabs()...)
+		if index < 0 {
+			continue
+		}
+
+		if ambiguous && (opts.NarrowNames || match != exactWord) {
+			return -1, value, false
+		}
+
+		p.recordName(e, value[offset:], matched, match == fuzzyWord)
+		return index, value[offset+len(matched):], true
+	}
+
+	return -1, value, false
+}
+
+// recordName records the name matched at the value start, if the names are recorded, along
+// with the language of the locale whose element matched it.
+func (p *nativeParser) recordName(elem *layoutElem, value string, matched string, fuzzy bool) {
+	if !p.recordNames {
+		return
+	}
+
+	language := elem.language
+	if language == "" {
+		language = p.language
+	}
+
+	offset := len(p.value) - len(value)
+	p.names = append(p.names, NameMatch{LayoutElem: elem.name, Value: matched, Offset: offset, Language: language, Fuzzy: fuzzy})
+}
+
+// skipAbbreviationPeriod skips the period following the short day or month name ending at
+// the value start, if the options accept it, and the layout offset is not a period.
+func skipAbbreviationPeriod(layout string, i int, value string, opts *Options) string {
+	if !opts.AbbreviationPeriods || !strings.HasPrefix(value, ".") || strings.HasPrefix(layout[i:], ".") {
+		return value
+	}
+	return value[1:]
+}
+
+// skipTimeZoneAbbreviation skips the time zone abbreviation read by the time package for
+// the MST layout element, such as CET or PDT.
+func skipTimeZoneAbbreviation(value string) (string, bool) {
+	n := 0
+	for n < len(value) && 'A' <= value[n] && value[n] <= 'Z' {
+		n++
+	}
+	if n < 3 || n > 5 {
+		return value, false
+	}
+	return value[n:], true
+}
+
+// nextStdChunk returns the kind of the next time package layout element, starting at the
+// layout offset.
+func nextStdChunk(layout string, i int) chunkKind {
+	for i < len(layout) {
+		kind, n := stdChunkAt(layout, i)
+		if kind != literalChunk {
+			return kind
+		}
+		i += n
+	}
+	return literalChunk
+}
+
+// daysBefore holds the number of days in a non-leap year before each month.
+var daysBefore = [...]int{0, 31, 59, 90, 120, 151, 181, 212, 243, 273, 304, 334, 365}
+
+func daysIn(month time.Month, year int) int {
+	if month == time.February && isLeap(year) {
+		return 29
+	}
+	return daysBefore[month] - daysBefore[month-1]
+}
+
+// getnum reads one or two digits, or exactly two if fixed is true.
+func getnum(s string, fixed bool) (int, string, bool) {
+	if !isDigitAt(s, 0) {
+		return 0, s, false
+	}
+	if !isDigitAt(s, 1) {
+		if fixed {
+			return 0, s, false
+		}
+		return int(s[0] - '0'), s[1:], true
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), s[2:], true
+}
+
+// getnum3 reads one to three digits, or exactly three if fixed is true.
+func getnum3(s string, fixed bool) (int, string, bool) {
+	var n, i int
+	for i = 0; i < 3 && isDigitAt(s, i); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if i == 0 || fixed && i != 3 {
+		return 0, s, false
+	}
+	return n, s[i:], true
+}
+
+// parseNanoseconds reads the fractional second of the first n value bytes, starting with
+// a comma or a period.
+func parseNanoseconds(value string, n int) (int, bool) {
+	if !isCommaOrPeriod(value[0]) {
+		return 0, false
+	}
+	if n > 10 {
+		n = 10
+	}
+
+	ns, ok := atoiDigits(value[1:n])
+	if !ok {
+		return 0, false
+	}
+
+	for i := n; i < 10; i++ {
+		ns *= 10
+	}
+	return ns, true
+}
+
+// atoiDigits returns the value of the digits, which must not be empty.
+func atoiDigits(s string) (int, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if !isDigitAt(s, i) {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, true
+}
+
+func isDigitAt(s string, i int) bool {
+	return i < len(s) && '0' <= s[i] && s[i] <= '9'
+}
+
+func isCommaOrPeriod(c byte) bool {
+	return c == '.' || c == ','
+}
+
+func startsWithSpace(value string) bool {
+	_, skipped := skipLeadingSpace(value, 0)
+	return skipped > 0
+}
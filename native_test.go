@@ -0,0 +1,230 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+// checkParseNative checks that parseNative returns the same time as parseTranslated, if
+// it parses the value, and returns whether it did.
+func checkParseNative(t *testing.T, layout string, value string, location *time.Location, locale Locale, opts *Options) bool {
+	t.Helper()
+
+	want, wantErr := parseTranslated(layout, nil, value, location, locale, opts)
+	got, ok := parseNative(layout, nil, value, location, locale, opts)
+	if !ok {
+		return false
+	}
+
+	if wantErr != nil {
+		t.Errorf("layout '%s' value '%s': expected error: '%v', got: %v", layout, value, wantErr, got)
+		return true
+	}
+
+	wantName, wantOffset := want.Zone()
+	gotName, gotOffset := got.Zone()
+	if !got.Equal(want) || got.Location().String() != want.Location().String() || gotName != wantName || gotOffset != wantOffset {
+		t.Errorf("layout '%s' value '%s': expected: %v (%s), got: %v (%s)", layout, value, want, want.Location(), got, got.Location())
+	}
+	return true
+}
+
+func TestParseNativeParseTests(t *testing.T) {
+	for _, test := range parseTests {
+		values := []ParseTestLocale{{lang: LocaleEn, value: test.stdValue}}
+		if test.locales != nil {
+			values = append(values, *test.locales...)
+		}
+
+		for _, lt := range values {
+			locale, err := NewDefaultLocale(lt.lang)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			for _, location := range []*time.Location{nil, defaultLocation} {
+				native := checkParseNative(t, test.format, lt.value, location, locale, &Options{})
+				if !native && lt.expectedErr == nil && !strings.Contains(test.format, "MST") {
+					t.Errorf("layout '%s' value '%s' (%s): expected the value to be parsed natively", test.format, lt.value, lt.lang)
+				}
+			}
+		}
+	}
+}
+
+func TestParseNative(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	esEras := newTestLocale(LocaleEs, esErasTestFields)
+	esPeriods := newTestLocale(LocaleEs, esDayPeriodsTestFields)
+
+	tests := []struct {
+		layout string
+		value  string
+		locale Locale
+		native bool
+	}{
+		{layout: "Monday, 2 January 2006 15:04:05.000 -0700", value: "jueves, 27 octubre 1988 11:53:29.123 +0200", locale: es, native: true},
+		{layout: "Jan _2 2006 15:04:05", value: "oct  7 1988 11:53:29.123456", locale: es, native: true},
+		{layout: "Jan __2 06 03:04:05 PM", value: "mar  90 88 11:53:29 p.m.", locale: es, native: true},
+		{layout: "002 2006 3:04 PM Z07:00", value: "366 2024 12:05 a.m. Z", locale: es, native: true},
+		{layout: "Jan __2 06", value: "oct  90 88", locale: es},
+		{layout: "2 Jan 2006 -07:00:00", value: "29 feb 2023 -03:00:00", locale: es},
+		{layout: "2 Jan 2006", value: "31  sept  2024", locale: es},
+		{layout: "2 Jan 2006", value: "2 ENE. 2024 extra", locale: es},
+		{layout: "2 Jan 2006", value: "2 abc 2024", locale: es},
+		{layout: "2 Jan 2006", value: "2\toct 2024", locale: es},
+		{layout: "15:04", value: "24:00", locale: es},
+		{layout: "Jan 2 2006 15:04 MST", value: "oct 27 1988 11:53 CET", locale: es},
+		{layout: "Jan 2 2006 3:04 Pm", value: "oct 27 1988 11:53 p. m.", locale: es},
+		{layout: "2 Jan 2006 AD", value: "29 feb 45 a. C.", locale: esEras, native: true},
+		{layout: "2 Jan 2006 AD", value: "29 feb 44 a. C.", locale: esEras},
+		{layout: "2 Jan 2006 Anno Domini", value: "15 mar 2024 después de Cristo", locale: esEras, native: true},
+		{layout: "2 Jan _2006 AD", value: "15 mar _2024 d. C.", locale: esEras, native: true},
+		{layout: "3:04 PM", value: "3:04 de la tarde", locale: esPeriods, native: true},
+		{layout: "3:04 PM", value: "11:04 de la noche", locale: esPeriods, native: true},
+		{layout: "15:04 PM", value: "23:04 de la noche", locale: esPeriods, native: true},
+		{layout: "3:04 PM", value: "13:04 de la noche", locale: esPeriods},
+		{layout: "01/02 03PM '06 -0700", value: "11/08  12a.m. '35 +0000", locale: es, native: true},
+		{layout: "January 2, 2006 at 3:04:05 PM", value: "agosto  17,  2008  at  11:22:55  p.m.", locale: es, native: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout+" "+tt.value, func(t *testing.T) {
+			for _, location := range []*time.Location{nil, defaultLocation} {
+				if native := checkParseNative(t, tt.layout, tt.value, location, tt.locale, &Options{}); native != tt.native {
+					t.Errorf("expected parsed natively: %t, got: %t", tt.native, native)
+				}
+			}
+		})
+	}
+}
+
+// TestParseNativeDifferential checks that the values parsed natively are parsed to the same
+// time by translating them, and parsing them with the time package.
+func TestParseNativeDifferential(t *testing.T) {
+	layouts := []string{
+		time.ANSIC, time.RFC822, time.RFC1123, time.RFC1123Z, time.RFC3339, time.Kitchen, time.Stamp,
+		"Monday, 2 January 2006 15:04:05",
+		"Mon Jan _2 15:04:05 2006",
+		"01/02 03PM '06 -0700",
+		"2 Jan 06 3:04 pm",
+		"02.01.2006 15:04:05.000",
+		"January 2, 2006 at 3:04:05 PM",
+		"__2 2006 Jan",
+		"2006-01-02T15:04:05.999999999Z07:00",
+		"Mon, 02 Jan 2006  15:04",
+	}
+
+	langs := []string{LocaleEn, LocaleEs, LocalePl, LocaleDe, LocaleFr, LocaleRu}
+	perturbations := []func(string) string{
+		func(s string) string { return s },
+		func(s string) string { return strings.ReplaceAll(s, " ", "  ") },
+		func(s string) string { return strings.Replace(s, " ", "", 1) },
+		func(s string) string { return strings.ToUpper(s) },
+		func(s string) string { return strings.ToLower(s) },
+		func(s string) string { return strings.Replace(s, " 0", " ", 1) },
+		func(s string) string { return s + " " },
+		func(s string) string { return " " + s },
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, lang := range langs {
+		locale, _ := NewDefaultLocale(lang)
+		for _, layout := range layouts {
+			for range 20 {
+				tm := time.Date(1900+r.IntN(200), time.Month(1+r.IntN(12)), 1+r.IntN(28), r.IntN(24), r.IntN(60), r.IntN(60), r.IntN(1e9), time.UTC)
+				formatted, err := FormatWithLocale(tm, layout, locale)
+				if err != nil {
+					// the locale does not support the layout
+					break
+				}
+
+				for _, perturb := range perturbations {
+					value := perturb(formatted)
+					got, ok := parseNative(layout, nil, value, nil, locale, &Options{})
+					if !ok {
+						continue
+					}
+
+					translated, err := TranslateWithLocale(layout, value, locale)
+					if err != nil {
+						t.Errorf("%s layout '%s' value '%s': expected no error, got: '%v'", lang, layout, value, err)
+						continue
+					}
+
+					want, err := time.Parse(layout, translated)
+					if err != nil || !want.Equal(got) {
+						t.Errorf("%s layout '%s' value '%s': expected: %v ('%v'), got: %v", lang, layout, value, want, err, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestParseNativeOptions(t *testing.T) {
+	zones := newTestLocale(LocaleEs, timeZoneTestFields)
+	if !checkParseNative(t, "2 Jan 2006 15:04 MST", "27 oct 1988 11:53 hora estándar de Irlanda", nil, zones, &Options{TimeZoneNames: true}) {
+		t.Error("expected the time zone name to be parsed natively")
+	}
+
+	narrow := newTestLocale(LocaleDe, narrowTestFields)
+	if !checkParseNative(t, "Mon 2 Jan 2006", "Mi 27 O 1988", nil, narrow, &Options{NarrowNames: true}) {
+		t.Error("expected the narrow names to be parsed natively")
+	}
+
+	if checkParseNative(t, "Mon 2 Jan 2006", "M 27 O 1988", nil, narrow, &Options{NarrowNames: true}) {
+		t.Error("expected the ambiguous narrow names not to be parsed natively")
+	}
+}
+
+func TestParseNativeVariableWidths(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+
+	// translate copies the literals byte by byte, so it can't parse the values whose
+	// elements are wider, or narrower, than the layout ones before a translated name.
+	tests := []struct {
+		layout   string
+		value    string
+		stdValue string
+	}{
+		{layout: "03:04:05.999 PM", value: "11:53:29 p.m.", stdValue: "11:53:29 PM"},
+		{layout: "03:04:05.999 PM", value: "11:53:29.1 p.m.", stdValue: "11:53:29.1 PM"},
+		{layout: "15:04:05 Monday", value: "11:53:29.123 lunes", stdValue: "11:53:29.123 Monday"},
+		{layout: "1/2/06 Monday", value: "10/27/88 jueves", stdValue: "10/27/88 Thursday"},
+		{layout: "2006-01-02 Z0700 Mon", value: "1988-10-27 Z jue", stdValue: "1988-10-27 Z Thu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			want, err := time.Parse(tt.layout, tt.stdValue)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			got, err := ParseWithLocale(tt.layout, tt.value, es)
+			if err != nil || !got.Equal(want) {
+				t.Errorf("expected: %v, got: %v ('%v')", want, got, err)
+			}
+		})
+	}
+}
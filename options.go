@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import "time"
+
+// Options holds the optional behaviors of the translation and parsing functions.
+// The zero value is the default behavior used by [TranslateWithLocale], [ParseWithLocale],
+// and [ParseInLocationWithLocale].
+type Options struct {
+	// NarrowNames enables matching the short day names layout element (Mon) against the
+	// locale min ("Mo") and narrow ("M") day names, and the short month names layout element
+	// (Jan) against the narrow month names. These names are only available for locales
+	// implementing the [NarrowLocale] interface. As narrow names are frequently shared by
+	// more than one day or month, values matching several names result in an
+	// ErrAmbiguousValue error.
+	NarrowNames bool
+
+	// TimeZoneNames enables matching the time zone layout element (MST) against the CLDR
+	// localized time zones names (e.g. "hora de verano de Europa central"), the exemplar
+	// cities (e.g. "hora de Madrid"), and the localized GMT formats (e.g. "UTC+3",
+	// "GMT+03:00"). The parsed time is returned in the matched time zone location, instead
+	// of the zero-offset fabricated location the time package uses for unknown time zones
+	// abbreviations. The metazones names use the time zone the CLDR defines as their golden
+	// zone (e.g. "Europe/Paris" for central European time). As the translated value would
+	// lose the location, it's only used by the parsing functions, and ignored by
+	// TranslateWithOptions. Names are available for locales implementing the
+	// [TimeZoneLocale] interface, while the GMT formats are supported by all locales.
+	TimeZoneNames bool
+
+	// CaseSensitive matches the names with the case they have in the locale tables,
+	// instead of ignoring it (e.g. "octubre" matches, but "Octubre" does not).
+	CaseSensitive bool
+
+	// Spaces sets how the value spaces are matched, see [SpacesMode].
+	Spaces SpacesMode
+
+	// IgnoreTrailingInput ignores the value following the last layout element, instead of
+	// reporting it as an error. As the translation does not validate the value, it's only
+	// used by the parsing functions.
+	IgnoreTrailingInput bool
+
+	// AbbreviationPeriods matches the abbreviated names with or without their trailing
+	// period (e.g. both "janv." and "janv" match "janv."), and skips the period following
+	// the short day and month names, unless the layout has it (e.g. "oct." matches "oct").
+	AbbreviationPeriods bool
+
+	// FuzzyNames matches the day and month names the value misspells against the closest
+	// locale name (e.g. "Setiembre", "Febraury", or "mierc"), when no name matches them
+	// exactly. The words are compared ignoring case, allowing one typo for the words with
+	// less than 8 letters, and two for the longer ones, while the words with less than 4
+	// letters are never matched fuzzily. Values as close to several names result in an
+	// ErrAmbiguousValue error. [ParseWithResult] reports the names that were matched
+	// fuzzily.
+	FuzzyNames bool
+
+	// AnyWidthNames matches the names layout elements against the locale names of any
+	// width, while the layout still sets the field they are read into: both January and
+	// Jan accept the long and short month names, Monday and Mon the long and short day
+	// names, and Anno Domini and AD the long, short, and narrow era names. The day and
+	// month names are also matched by the prefixes of at least 3 letters the value
+	// abbreviates them with (e.g. "septiem"), as long as no other name shares them,
+	// otherwise resulting in an ErrAmbiguousValue error.
+	AnyWidthNames bool
+
+	// FallbackLocales holds the locales whose names are matched, in order, when the layout
+	// locale names do not match the value, for the values mixing languages (e.g. a Spanish
+	// month name with an English week day name). The names matched by each element keep
+	// the field the layout sets, and [ParseWithResult] reports the language of the locale
+	// that matched them.
+	FallbackLocales []Locale
+
+	// EnglishFallback matches the English names used by the time package (e.g. "January",
+	// "Mon", "PM", or "AD") after the FallbackLocales ones, for the values falling back to
+	// English names.
+	EnglishFallback bool
+}
+
+// SpacesMode is the way the value spaces are matched against the layout spaces.
+type SpacesMode uint8
+
+const (
+	// DefaultSpaces matches the layout spaces as the time package does, where a run of
+	// layout spaces matches any run of value spaces, and skips any spaces preceding the
+	// names.
+	DefaultSpaces SpacesMode = iota
+	// StrictSpaces matches each layout space against a single value space, and no spaces
+	// are skipped.
+	StrictSpaces
+	// LenientSpaces matches a run of layout spaces against any run of Unicode white space
+	// characters, such as tabs, line breaks, or no-break spaces, and the names spaces
+	// likewise, ignoring the value leading and trailing white space.
+	LenientSpaces
+)
+
+// StrictOptions returns the options validating the values, with case-sensitive names
+// and strict spaces.
+func StrictOptions() Options {
+	return Options{CaseSensitive: true, Spaces: StrictSpaces}
+}
+
+// LenientOptions returns the options accepting the values found on free text, with lenient
+// spaces, ignoring the trailing input, and accepting abbreviations without their periods.
+func LenientOptions() Options {
+	return Options{Spaces: LenientSpaces, IgnoreTrailingInput: true, AbbreviationPeriods: true}
+}
+
+// matchesNames reports whether the names are matched differently than by the default
+// behavior, so they are not matched using the locale tries.
+func (o *Options) matchesNames() bool {
+	return o.CaseSensitive || o.Spaces != DefaultSpaces || o.AbbreviationPeriods
+}
+
+// hasFallbacks reports whether the options set locales whose names are matched when the
+// layout locale ones do not match the value.
+func (o *Options) hasFallbacks() bool {
+	return len(o.FallbackLocales) > 0 || o.EnglishFallback
+}
+
+// readsValue reports whether the value is read differently than by the time package, so
+// only the mismatches reported by parseNative are returned.
+func (o *Options) readsValue() bool {
+	return o.Spaces != DefaultSpaces || o.IgnoreTrailingInput
+}
+
+// TranslateWithOptions is like TranslateWithLocale, but it receives an Options argument
+// enabling optional translation behaviors.
+func TranslateWithOptions(layout string, value string, locale Locale, opts Options) (string, error) {
+	return translate(layout, value, locale, &opts, nil)
+}
+
+// ParseWithOptions is like ParseWithLocale, but it receives an Options argument enabling
+// optional translation behaviors.
+func ParseWithOptions(layout string, value string, locale Locale, opts Options) (time.Time, error) {
+	return parse(layout, nil, value, nil, locale, &opts)
+}
+
+// ParseInLocationWithOptions is like ParseInLocationWithLocale, but it receives an Options
+// argument enabling optional translation behaviors.
+func ParseInLocationWithOptions(layout string, value string, location *time.Location, locale Locale, opts Options) (time.Time, error) {
+	return parse(layout, nil, value, location, locale, &opts)
+}
@@ -0,0 +1,235 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var narrowTestFields = map[int][]string{
+	minDayNamesField:      {"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	narrowDayNamesField:   {"S", "M", "D", "M", "D", "F", "S"},
+	narrowMonthNamesField: {"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+}
+
+func TestNarrowNamesOption(t *testing.T) {
+	locale := newTestLocale(LocaleDe, narrowTestFields)
+	opts := Options{NarrowNames: true}
+
+	tests := []struct {
+		name   string
+		layout string
+		value  string
+		want   string
+	}{
+		{
+			name:   "MinDayName",
+			layout: "Mon, 2 Jan 2006",
+			value:  "Di, 29 Okt. 2024",
+			want:   "Tue, 29 Oct 2024",
+		},
+		{
+			name:   "UniqueNarrowNames",
+			layout: "Mon 2 Jan 2006",
+			value:  "F 1 N 2024",
+			want:   "Fri 1 Nov 2024",
+		},
+		{
+			name:   "LongerNamesPrecedence",
+			layout: "Mon 2 Jan 2006",
+			value:  "Do. 31 Okt. 2024",
+			want:   "Thu 31 Oct 2024",
+		},
+		{
+			name:   "LongNamesNotAffected",
+			layout: "Monday 2 January 2006",
+			value:  "Donnerstag 31 Oktober 2024",
+			want:   "Thursday 31 October 2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateWithOptions(tt.layout, tt.value, locale, opts)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected value %q, got: %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		value := "Di, 29 Okt. 2024"
+		_, err := TranslateWithOptions("Mon, 2 Jan 2006", value, locale, Options{})
+		expected := newLayoutMismatchError("Mon", value)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("AmbiguousNarrowDay", func(t *testing.T) {
+		value := "D 29 Okt. 2024"
+		_, err := TranslateWithOptions("Mon 2 Jan 2006", value, locale, opts)
+		expected := newAmbiguousValueError("Mon", value, "D")
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("AmbiguousNarrowMonth", func(t *testing.T) {
+		value := "29 J 2024"
+		_, err := TranslateWithOptions("2 Jan 2006", value, locale, opts)
+		expected := newAmbiguousValueError("Jan", value, "J")
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("NonNarrowLocale", func(t *testing.T) {
+		locale, err := NewDefaultLocale(LocaleDe)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		got, err := TranslateWithOptions("Mon 2 Jan 2006", "Do. 31 Okt. 2024", locale, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		if got != "Thu 31 Oct 2024" {
+			t.Errorf("expected value \"Thu 31 Oct 2024\", got: %q", got)
+		}
+	})
+}
+
+func TestParseWithOptions(t *testing.T) {
+	locale := newTestLocale(LocaleDe, narrowTestFields)
+	opts := Options{NarrowNames: true}
+	expected := time.Date(2024, time.October, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ParseWithOptions", func(t *testing.T) {
+		got, err := ParseWithOptions("Mon, 2 Jan 2006", "Di, 29 O 2024", locale, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		if !got.Equal(expected) {
+			t.Errorf("expected: %v, got: %v", expected, got)
+		}
+	})
+
+	t.Run("ParseInLocationWithOptions", func(t *testing.T) {
+		got, err := ParseInLocationWithOptions("Mon, 2 Jan 2006", "Di, 29 O 2024", defaultLocation, locale, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		want := time.Date(2024, time.October, 29, 0, 0, 0, 0, defaultLocation)
+		if !got.Equal(want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+}
+
+func TestStrictnessOptions(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+	fr, _ := NewDefaultLocale(LocaleFr)
+	expected := time.Date(1988, time.October, 27, 11, 53, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		layout string
+		value  string
+		locale Locale
+		opts   Options
+		// offset holds the ErrParse offset, or -1 if the value must be parsed
+		offset int
+	}{
+		{name: "CaseInsensitive", layout: "2 January 2006 15:04", value: "27 OCTUBRE 1988 11:53", locale: es, offset: -1},
+		{name: "CaseSensitive", layout: "2 January 2006 15:04", value: "27 Octubre 1988 11:53", locale: es, opts: Options{CaseSensitive: true}, offset: 3},
+		{name: "CaseSensitiveMatch", layout: "2 January 2006 15:04", value: "27 octubre 1988 11:53", locale: es, opts: Options{CaseSensitive: true}, offset: -1},
+		{name: "DefaultSpaces", layout: "2 January 2006 15:04", value: "27   octubre 1988 11:53", locale: es, offset: -1},
+		{name: "StrictSpaces", layout: "2 January 2006 15:04", value: "27  octubre 1988 11:53", locale: es, opts: Options{Spaces: StrictSpaces}, offset: 3},
+		{name: "StrictSpacesTab", layout: "2 January 2006 15:04", value: "27\toctubre 1988 11:53", locale: es, opts: Options{Spaces: StrictSpaces}, offset: 2},
+		{name: "StrictSpacesLayout", layout: "2  January 2006 15:04", value: "27  octubre 1988 11:53", locale: es, opts: Options{Spaces: StrictSpaces}, offset: -1},
+		{name: "StrictSpacesNumbers", layout: "2 January 2006 15:04", value: "27 octubre  1988 11:53", locale: es, opts: Options{Spaces: StrictSpaces}, offset: 11},
+		{name: "LenientSpaces", layout: "2 January 2006 15:04", value: " 27\toctubre  1988\n11:53 ", locale: es, opts: Options{Spaces: LenientSpaces}, offset: -1},
+		{name: "TrailingInput", layout: "2 January 2006 15:04", value: "27 octubre 1988 11:53 hrs", locale: es, offset: 21},
+		{name: "IgnoreTrailingInput", layout: "2 January 2006 15:04", value: "27 octubre 1988 11:53 hrs", locale: es, opts: Options{IgnoreTrailingInput: true}, offset: -1},
+		{name: "IgnoreTrailingInputTimeZone", layout: "2 January 2006 15:04 MST", value: "27 octubre 1988 11:53 UTC hrs", locale: es, opts: Options{IgnoreTrailingInput: true}, offset: -1},
+		{name: "AbbreviationPeriods", layout: "2 Jan 2006 15:04", value: "27 oct. 1988 11:53", locale: es, opts: Options{AbbreviationPeriods: true}, offset: -1},
+		{name: "AbbreviationPeriodsLayout", layout: "2 Jan. 2006 15:04", value: "27 oct. 1988 11:53", locale: es, opts: Options{AbbreviationPeriods: true}, offset: -1},
+		{name: "AbbreviationWithoutPeriod", layout: "2 Jan 2006 15:04", value: "27 oct 1988 11:53", locale: fr, opts: Options{AbbreviationPeriods: true}, offset: -1},
+		{name: "AbbreviationPeriodsDisabled", layout: "2 Jan 2006 15:04", value: "27 oct 1988 11:53", locale: fr, offset: 3},
+		{name: "StrictOptions", layout: "2 January 2006 15:04", value: "27 octubre 1988 11:53", locale: es, opts: StrictOptions(), offset: -1},
+		{name: "LenientOptions", layout: "Mon 2 Jan 2006 15:04", value: "jue\t27 oct. 1988 11:53 (hora local)", locale: es, opts: LenientOptions(), offset: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parseFns := map[string]func() (time.Time, error){
+				"ParseWithOptions": func() (time.Time, error) {
+					return ParseWithOptions(tt.layout, tt.value, tt.locale, tt.opts)
+				},
+				"Layout.ParseBytes": func() (time.Time, error) {
+					layout, err := CompileLayoutWithOptions(tt.layout, tt.locale, tt.opts)
+					if err != nil {
+						return time.Time{}, err
+					}
+					return layout.ParseBytes([]byte(tt.value))
+				},
+			}
+
+			for name, fn := range parseFns {
+				got, err := fn()
+				if tt.offset < 0 {
+					if err != nil || !got.Equal(expected) {
+						t.Errorf("%s: expected: %v, got: %v ('%v')", name, expected, got, err)
+					}
+					continue
+				}
+
+				var e *ErrParse
+				if !errors.As(err, &e) || e.Offset != tt.offset {
+					t.Errorf("%s: expected an error at offset %d, got: '%v'", name, tt.offset, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateWithStrictnessOptions(t *testing.T) {
+	es, _ := NewDefaultLocale(LocaleEs)
+
+	got, err := TranslateWithOptions("Mon 2 Jan 2006", " jue.\t27  oct. 1988 ", es, LenientOptions())
+	if err != nil || got != "Thu 27 Oct 1988" {
+		t.Errorf("expected: 'Thu 27 Oct 1988', got: '%s' ('%v')", got, err)
+	}
+
+	value := "jue 27 Oct 1988"
+	_, err = TranslateWithOptions("Mon 2 Jan 2006", value, es, StrictOptions())
+	expected := newLayoutMismatchError("Jan", value)
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error: '%v', got: '%v'", expected, err)
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strconv"
+	"strings"
+)
+
+// pluralCategory returns the CLDR plural category (e.g. "one", "few") of the integer n,
+// using the plural rules flattened as category and condition pairs. If no condition
+// matches n, it returns the "other" category.
+func pluralCategory(rules []string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+
+	for i := 0; i+1 < len(rules); i += 2 {
+		if rules[i+1] != "" && matchPluralCondition(rules[i+1], n) {
+			return rules[i]
+		}
+	}
+
+	return "other"
+}
+
+// matchPluralCondition evaluates the CLDR plural rule condition (e.g. "i = 1 and v = 0")
+// for the integer n. As integers have no visible fraction digits, the v, w, f, t, c and
+// e operands are zero, and the n and i operands are n.
+func matchPluralCondition(condition string, n int) bool {
+	for _, and := range strings.Split(condition, " or ") {
+		matched := true
+		for _, relation := range strings.Split(and, " and ") {
+			if !matchPluralRelation(strings.TrimSpace(relation), n) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPluralRelation evaluates a relation, such as "n % 10 = 2..4,6" or "i != 1".
+func matchPluralRelation(relation string, n int) bool {
+	expr, ranges, negated := strings.Cut(relation, "!=")
+	if !negated {
+		var ok bool
+		expr, ranges, ok = strings.Cut(relation, "=")
+		if !ok {
+			return false
+		}
+	}
+
+	operand, modulus, hasModulus := strings.Cut(strings.TrimSpace(expr), "%")
+	var value int
+	switch strings.TrimSpace(operand) {
+	case "n", "i":
+		value = n
+	case "v", "w", "f", "t", "c", "e":
+		value = 0
+	default:
+		return false
+	}
+
+	if hasModulus {
+		m, err := strconv.Atoi(strings.TrimSpace(modulus))
+		if err != nil || m == 0 {
+			return false
+		}
+		value %= m
+	}
+
+	in := false
+	for _, r := range strings.Split(strings.TrimSpace(ranges), ",") {
+		from, to, isRange := strings.Cut(r, "..")
+		low, err := strconv.Atoi(from)
+		if err != nil {
+			return false
+		}
+
+		high := low
+		if isRange {
+			if high, err = strconv.Atoi(to); err != nil {
+				return false
+			}
+		}
+
+		if value >= low && value <= high {
+			in = true
+			break
+		}
+	}
+
+	return in != negated
+}
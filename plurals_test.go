@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPluralCategory(t *testing.T) {
+	pl := []string{
+		"few", "v = 0 and i % 10 = 2..4 and i % 100 != 12..14",
+		"many", "v = 0 and i != 1 and i % 10 = 0..1 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 12..14",
+		"one", "i = 1 and v = 0",
+		"other", "",
+	}
+	ar := []string{
+		"few", "n % 100 = 3..10",
+		"many", "n % 100 = 11..99",
+		"one", "n = 1",
+		"two", "n = 2",
+		"zero", "n = 0",
+	}
+	fr := []string{
+		"many", "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+		"one", "i = 0,1",
+	}
+
+	tests := []struct {
+		rules []string
+		n     int
+		want  string
+	}{
+		{rules: pl, n: 1, want: "one"},
+		{rules: pl, n: 3, want: "few"},
+		{rules: pl, n: 13, want: "many"},
+		{rules: pl, n: 22, want: "few"},
+		{rules: pl, n: 25, want: "many"},
+		{rules: pl, n: -2, want: "few"},
+		{rules: ar, n: 0, want: "zero"},
+		{rules: ar, n: 2, want: "two"},
+		{rules: ar, n: 105, want: "few"},
+		{rules: ar, n: 111, want: "many"},
+		{rules: ar, n: 100, want: "other"},
+		{rules: fr, n: 0, want: "one"},
+		{rules: fr, n: 2, want: "other"},
+		{rules: fr, n: 1000000, want: "many"},
+		{rules: nil, n: 1, want: "other"},
+	}
+
+	for _, tt := range tests {
+		if got := pluralCategory(tt.rules, tt.n); got != tt.want {
+			t.Errorf("%v %d: expected category %q, got: %q", tt.rules, tt.n, tt.want, got)
+		}
+	}
+}
+
+func TestPluralCategoryLocales(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{lang: LocalePl, n: 1, want: "one"},
+		{lang: LocalePl, n: 3, want: "few"},
+		{lang: LocalePl, n: 12, want: "many"},
+		{lang: LocaleAr, n: 2, want: "two"},
+		{lang: LocaleAr, n: 111, want: "many"},
+		{lang: LocaleFr, n: 0, want: "one"},
+		{lang: LocaleFr, n: 1000000, want: "many"},
+		{lang: LocaleEn, n: 2, want: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.lang, tt.n), func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, pluralRulesField)
+
+			table, _ := getTable(tt.lang)
+			if got := pluralCategory(table[pluralRulesField], tt.n); got != tt.want {
+				t.Errorf("expected category %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,449 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// relativeClockLayouts holds the time of day layouts accepted by the relative time
+// parsing functions, combined with day or longer relative times (e.g. "ayer 11:53").
+var relativeClockLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"3:04:05 PM",
+	"3:04 PM",
+	"3:04:05PM",
+	"3:04PM",
+	"PM 3:04:05",
+	"PM 3:04",
+	"PM3:04:05",
+	"PM3:04",
+	"3 PM",
+	"3PM",
+}
+
+// ParseRelative parses a localized relative time value, such as "ayer", "hace 3 días",
+// "vor 2 Stunden" or "next Monday", returning the time it represents relatively to the
+// reference time. It uses the CLDR relative time names (e.g. "yesterday") and patterns
+// (e.g. "{0} days ago") of the years, quarters, months, weeks, days, hours, minutes,
+// seconds and week days fields, in all widths and plural categories.
+//
+// Relative names and patterns shift the reference time by the relative amount, keeping
+// its time of day. Week days names move to the given week day: the next one for future
+// values (e.g. "next Monday"), the previous one for past values (e.g. "last Monday"),
+// and the next one, or the reference day, for current values (e.g. "this Monday").
+// Relative values of days and longer fields can be combined with a time of day (e.g.
+// "hoy 11:53", "ayer a las 3:04 p. m."), which is translated using the locale day
+// periods, and replaces the reference time of day.
+//
+// The language argument must be a well-formed BCP 47 language tag, e.g ("en", "en-US") and
+// a known locale. If no data is found for the language, it returns ErrUnsupportedLocale.
+// If the value does not contain a relative time, it returns an ErrRelativeTimeMismatch.
+//
+// To execute several parses for the same locale, use [ParseRelativeWithLocale] as it performs better.
+func ParseRelative(value string, lang string, reference time.Time) (time.Time, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseRelativeWithLocale(value, locale, reference)
+}
+
+// ParseRelativeWithLocale is like ParseRelative, but instead of receiving a BCP 47 language
+// tag argument, it receives a built [lunes.Locale], avoiding looking up existing data in each
+// operation and allowing extensibility. The locale must implement the [RelativeTimeLocale]
+// interface, otherwise it results in an ErrUnsupportedLayoutElem error.
+func ParseRelativeWithLocale(value string, locale Locale, reference time.Time) (time.Time, error) {
+	relativeTimes, ok := locale.(RelativeTimeLocale)
+	if !ok {
+		return time.Time{}, newUnsupportedLayoutElemError("relative time", locale)
+	}
+
+	tabs := [][]string{
+		relativeTimes.LongRelativeTimes(),
+		relativeTimes.ShortRelativeTimes(),
+		relativeTimes.NarrowRelativeTimes(),
+	}
+
+	if allEmpty(tabs) {
+		return time.Time{}, newUnsupportedLayoutElemError("relative time", locale)
+	}
+
+	val := transliterateDigits(value, locale)
+
+	// relative times might be preceded by other words, such as a time of day
+	var match relativeMatch
+	for offset := 0; offset < len(val) && !match.ok; offset++ {
+		if offset > 0 && !unicode.IsSpace(rune(val[offset-1])) {
+			continue
+		}
+		match = matchRelativeTime(val, offset, tabs)
+	}
+
+	if !match.ok {
+		return time.Time{}, newRelativeTimeMismatchError(value)
+	}
+
+	t := match.shift(reference)
+	rest := strings.TrimSpace(val[:match.offset] + " " + val[match.offset+match.length:])
+	if rest == "" {
+		return t, nil
+	}
+
+	if match.field == "hour" || match.field == "minute" || match.field == "second" {
+		return time.Time{}, newRelativeTimeMismatchError(value)
+	}
+
+	clock, ok := parseRelativeClock(rest, locale)
+	if !ok {
+		return time.Time{}, newRelativeTimeMismatchError(value)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, t.Location()), nil
+}
+
+// RelativeTimeStyle is the width of the relative times written by [FormatRelative].
+type RelativeTimeStyle int
+
+const (
+	// RelativeTimeLong uses the long relative times (e.g. "in 3 hours").
+	RelativeTimeLong RelativeTimeStyle = iota
+	// RelativeTimeShort uses the short relative times (e.g. "in 3 hr.").
+	RelativeTimeShort
+	// RelativeTimeNarrow uses the narrow relative times (e.g. "in 3h").
+	RelativeTimeNarrow
+)
+
+// FormatRelative returns the localized relative time of t from the reference time, such as
+// "hace 3 días" or "dans 2 heures", written using the CLDR relative times of the given style,
+// and the language plural rules. It is the inverse of [ParseRelative].
+//
+// The relative time uses the largest field whose amount is not zero: seconds, minutes and
+// hours are counted by elapsed time, while days, weeks, months and years are counted by
+// calendar dates, in the reference location. Relative names (e.g. "yesterday") are used
+// instead of patterns when the locale provides them. Short and narrow styles fall back to
+// the wider ones if the locale does not define them.
+//
+// The language argument must be a well-formed BCP 47 language tag, e.g ("en", "en-US") and
+// a known locale. If no data is found for the language, it returns ErrUnsupportedLocale.
+// If the locale has no relative times, or the style is unknown, it results in an
+// ErrUnsupportedLayoutElem error.
+//
+// To execute several formats for the same locale, use [FormatRelativeWithLocale] as it performs better.
+func FormatRelative(t time.Time, reference time.Time, lang string, style RelativeTimeStyle) (string, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatRelativeWithLocale(t, reference, locale, style)
+}
+
+// FormatRelativeWithLocale is like FormatRelative, but instead of receiving a BCP 47 language
+// tag argument, it receives a built [lunes.Locale], avoiding looking up existing data in each
+// operation and allowing extensibility. The locale must implement the [RelativeTimeLocale]
+// interface, and optionally the [PluralRulesLocale] interface, otherwise, the "other" plural
+// category is used.
+func FormatRelativeWithLocale(t time.Time, reference time.Time, locale Locale, style RelativeTimeStyle) (string, error) {
+	relativeTimes, ok := locale.(RelativeTimeLocale)
+	if !ok {
+		return "", newUnsupportedLayoutElemError("relative time", locale)
+	}
+
+	if style < RelativeTimeLong || style > RelativeTimeNarrow {
+		return "", newUnsupportedLayoutElemError("relative time style", locale)
+	}
+
+	// the narrower styles fall back to the wider ones
+	tabs := [][]string{
+		relativeTimes.NarrowRelativeTimes(),
+		relativeTimes.ShortRelativeTimes(),
+		relativeTimes.LongRelativeTimes(),
+	}[RelativeTimeNarrow-style:]
+
+	field, amount := relativeAmount(t, reference)
+	if name, ok := lookupPair(tabs, field+":"+strconv.Itoa(amount)); ok {
+		return name, nil
+	}
+
+	direction := "future"
+	if amount < 0 {
+		direction = "past"
+	}
+
+	var rules []string
+	if plurals, ok := locale.(PluralRulesLocale); ok {
+		rules = plurals.PluralRules()
+	}
+
+	pattern, ok := lookupPair(tabs, field+":"+direction+":"+pluralCategory(rules, amount))
+	if !ok {
+		pattern, ok = lookupPair(tabs, field+":"+direction+":other")
+	}
+
+	if !ok {
+		return "", newUnsupportedLayoutElemError("relative time", locale)
+	}
+
+	if amount < 0 {
+		amount = -amount
+	}
+
+	return strings.Replace(pattern, "{0}", strconv.Itoa(amount), 1), nil
+}
+
+// relativeAmount returns the largest relative time field, and its amount, between the
+// reference and the t times.
+func relativeAmount(t time.Time, reference time.Time) (string, int) {
+	d := t.Sub(reference)
+	switch abs := d.Abs(); {
+	case abs < time.Minute:
+		return "second", int(d / time.Second)
+	case abs < time.Hour:
+		return "minute", int(d / time.Minute)
+	case abs < 24*time.Hour:
+		return "hour", int(d / time.Hour)
+	}
+
+	t = t.In(reference.Location())
+	days := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(reference.Year(), reference.Month(), reference.Day(), 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+	if days > -7 && days < 7 {
+		return "day", days
+	}
+
+	months := (t.Year()-reference.Year())*12 + int(t.Month()-reference.Month())
+	if months > 0 && t.Day() < reference.Day() {
+		months--
+	} else if months < 0 && t.Day() > reference.Day() {
+		months++
+	}
+
+	switch {
+	case months == 0:
+		return "week", days / 7
+	case months > -12 && months < 12:
+		return "month", months
+	}
+
+	return "year", months / 12
+}
+
+// lookupPair returns the value of the first key and value pair of the tables matching the key.
+func lookupPair(tabs [][]string, key string) (string, bool) {
+	for _, tab := range tabs {
+		for i := 0; i+1 < len(tab); i += 2 {
+			if tab[i] == key && tab[i+1] != "" {
+				return tab[i+1], true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// relativeMatch holds a relative time matched on the value.
+type relativeMatch struct {
+	ok     bool
+	offset int
+	length int
+	// field is the CLDR relative time field (e.g. "day", "mon"), and amount the
+	// number of fields units it represents, negative for past values.
+	field  string
+	amount int
+}
+
+func (m *relativeMatch) shift(reference time.Time) time.Time {
+	switch m.field {
+	case "year":
+		return reference.AddDate(m.amount, 0, 0)
+	case "quarter":
+		return reference.AddDate(0, 3*m.amount, 0)
+	case "month":
+		return reference.AddDate(0, m.amount, 0)
+	case "week":
+		return reference.AddDate(0, 0, 7*m.amount)
+	case "day":
+		return reference.AddDate(0, 0, m.amount)
+	case "hour":
+		return reference.Add(time.Duration(m.amount) * time.Hour)
+	case "minute":
+		return reference.Add(time.Duration(m.amount) * time.Minute)
+	case "second":
+		return reference.Add(time.Duration(m.amount) * time.Second)
+	}
+
+	weekday := time.Weekday(slices.Index(relativeWeekdays, m.field))
+	days := int(weekday-reference.Weekday()+7) % 7
+	switch {
+	case m.amount > 0 && days == 0:
+		days = 7
+	case m.amount < 0:
+		days -= 7
+	}
+
+	return reference.AddDate(0, 0, days)
+}
+
+var relativeWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// matchRelativeTime finds the longest relative time name or pattern matching the value
+// at the offset position. Relative times tables are flattened key and value pairs, keyed
+// by the field and relative type (e.g. "day:-1"), or by the field, the future or past
+// type, and the plural category of patterns (e.g. "day:past:other").
+func matchRelativeTime(value string, offset int, tabs [][]string) relativeMatch {
+	match := relativeMatch{offset: offset}
+	val := value[offset:]
+
+	for _, tab := range tabs {
+		for i := 0; i+1 < len(tab); i += 2 {
+			field, relative, _ := strings.Cut(tab[i], ":")
+			direction, _, isPattern := strings.Cut(relative, ":")
+
+			length, amount := 0, 0
+			if isPattern {
+				length, amount = matchRelativePattern(val, tab[i+1])
+				if direction == "past" {
+					amount = -amount
+				}
+			} else if hasPrefixFold(val, tab[i+1]) {
+				length = len(tab[i+1])
+				amount, _ = strconv.Atoi(relative)
+			}
+
+			if length > match.length && isWordEnd(val, length) {
+				match.ok = true
+				match.length, match.field, match.amount = length, field, amount
+			}
+		}
+	}
+
+	return match
+}
+
+// matchRelativePattern matches a relative time pattern (e.g. "hace {0} días"), returning
+// the matched value length and the pattern number, or a zero length if it does not match.
+func matchRelativePattern(value string, pattern string) (int, int) {
+	prefix, suffix, ok := strings.Cut(pattern, "{0}")
+	if !ok || !hasPrefixFold(value, prefix) {
+		return 0, 0
+	}
+
+	n := len(prefix)
+	digits := 0
+	for n+digits < len(value) && value[n+digits] >= '0' && value[n+digits] <= '9' {
+		digits++
+	}
+
+	if digits == 0 || !hasPrefixFold(value[n+digits:], suffix) {
+		return 0, 0
+	}
+
+	amount, err := strconv.Atoi(value[n : n+digits])
+	if err != nil {
+		return 0, 0
+	}
+
+	return n + digits + len(suffix), amount
+}
+
+// parseRelativeClock parses the time of day contained in the value, ignoring the words
+// preceding or following it (e.g. "a las 11:53"), as long as they have no digits.
+func parseRelativeClock(value string, locale Locale) (time.Time, bool) {
+	starts, ends := wordBoundaries(value)
+	for _, start := range starts {
+		for i := len(ends) - 1; i >= 0 && ends[i] > start; i-- {
+			end := ends[i]
+			if strings.ContainsFunc(value[:start]+value[end:], unicode.IsDigit) {
+				continue
+			}
+
+			for _, layout := range relativeClockLayouts {
+				t, err := parseValue(layout, nil, value[start:end], nil, locale, &Options{})
+				if err == nil {
+					return t, true
+				}
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// wordBoundaries returns the start and end positions of the value words.
+func wordBoundaries(value string) (starts []int, ends []int) {
+	inWord := false
+	for i, r := range value {
+		space := unicode.IsSpace(r)
+		if !space && !inWord {
+			starts = append(starts, i)
+		} else if space && inWord {
+			ends = append(ends, i)
+		}
+		inWord = !space
+	}
+
+	if inWord {
+		ends = append(ends, len(value))
+	}
+
+	return starts, ends
+}
+
+// isWordEnd reports whether the value position is not followed by a letter.
+func isWordEnd(value string, offset int) bool {
+	if offset >= len(value) {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(value[offset:])
+	return !unicode.IsLetter(r)
+}
+
+// ErrRelativeTimeMismatch indicates that a provided value does not contain a relative time
+// expression supported by the locale.
+type ErrRelativeTimeMismatch struct {
+	Value string
+}
+
+func (r *ErrRelativeTimeMismatch) Error() string {
+	return fmt.Sprintf(`value "%s" does not match any relative time`, r.Value)
+}
+
+func (r *ErrRelativeTimeMismatch) Is(err error) bool {
+	var target *ErrRelativeTimeMismatch
+	if ok := errors.As(err, &target); ok {
+		return r.Value == target.Value
+	}
+	return false
+}
+
+func newRelativeTimeMismatchError(value string) error {
+	return &ErrRelativeTimeMismatch{
+		Value: value,
+	}
+}
@@ -0,0 +1,280 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var esRelativeTimesTestFields = map[int][]string{
+	longRelativeTimesField: {
+		"day:-2", "anteayer",
+		"day:-1", "ayer",
+		"day:0", "hoy",
+		"day:1", "mañana",
+		"day:future:one", "dentro de {0} día",
+		"day:future:other", "dentro de {0} días",
+		"day:past:one", "hace {0} día",
+		"day:past:other", "hace {0} días",
+		"hour:past:one", "hace {0} hora",
+		"hour:past:other", "hace {0} horas",
+		"mon:-1", "el lunes pasado",
+		"mon:0", "este lunes",
+		"mon:1", "el próximo lunes",
+		"wed:0", "este miércoles",
+		"week:-1", "la semana pasada",
+		"month:future:other", "dentro de {0} meses",
+	},
+	shortRelativeTimesField: {
+		"hour:past:other", "hace {0} h",
+		"day:past:other", "hace {0} d",
+	},
+}
+
+func TestParseRelative(t *testing.T) {
+	es := newTestLocale(LocaleEs, esRelativeTimesTestFields)
+	de := newTestLocale(LocaleDe, map[int][]string{
+		longRelativeTimesField: {
+			"day:past:one", "vor {0} Tag",
+			"day:past:other", "vor {0} Tagen",
+			"hour:future:other", "in {0} Stunden",
+		},
+	})
+
+	// Wednesday
+	reference := time.Date(2024, time.October, 16, 15, 30, 0, 0, defaultLocation)
+
+	tests := []struct {
+		name   string
+		value  string
+		locale Locale
+		want   time.Time
+	}{
+		{name: "Yesterday", value: "ayer", locale: es, want: time.Date(2024, time.October, 15, 15, 30, 0, 0, defaultLocation)},
+		{name: "LongestName", value: "anteayer", locale: es, want: time.Date(2024, time.October, 14, 15, 30, 0, 0, defaultLocation)},
+		{name: "Today", value: "Hoy", locale: es, want: reference},
+		{name: "TodayClock", value: "hoy 11:53", locale: es, want: time.Date(2024, time.October, 16, 11, 53, 0, 0, defaultLocation)},
+		{name: "ClockBefore", value: "11:53:29 de ayer", locale: es, want: time.Date(2024, time.October, 15, 11, 53, 29, 0, defaultLocation)},
+		{name: "ClockDayPeriod", value: "ayer a las 3:04 p.m.", locale: es, want: time.Date(2024, time.October, 15, 15, 4, 0, 0, defaultLocation)},
+		{name: "PastHours", value: "hace 3 horas", locale: es, want: time.Date(2024, time.October, 16, 12, 30, 0, 0, defaultLocation)},
+		{name: "PastHour", value: "hace 1 hora", locale: es, want: time.Date(2024, time.October, 16, 14, 30, 0, 0, defaultLocation)},
+		{name: "ShortPattern", value: "hace 2 d", locale: es, want: time.Date(2024, time.October, 14, 15, 30, 0, 0, defaultLocation)},
+		{name: "FutureDays", value: "dentro de 20 días", locale: es, want: time.Date(2024, time.November, 5, 15, 30, 0, 0, defaultLocation)},
+		{name: "FutureMonths", value: "dentro de 3 meses", locale: es, want: time.Date(2025, time.January, 16, 15, 30, 0, 0, defaultLocation)},
+		{name: "LastWeek", value: "la semana pasada", locale: es, want: time.Date(2024, time.October, 9, 15, 30, 0, 0, defaultLocation)},
+		{name: "LastWeekday", value: "el lunes pasado", locale: es, want: time.Date(2024, time.October, 14, 15, 30, 0, 0, defaultLocation)},
+		{name: "ThisWeekday", value: "este lunes", locale: es, want: time.Date(2024, time.October, 21, 15, 30, 0, 0, defaultLocation)},
+		{name: "ThisSameWeekday", value: "este miércoles 9:00", locale: es, want: time.Date(2024, time.October, 16, 9, 0, 0, 0, defaultLocation)},
+		{name: "NextWeekday", value: "el próximo lunes", locale: es, want: time.Date(2024, time.October, 21, 15, 30, 0, 0, defaultLocation)},
+		{name: "GermanPastDays", value: "vor 2 Tagen", locale: de, want: time.Date(2024, time.October, 14, 15, 30, 0, 0, defaultLocation)},
+		{name: "GermanFutureHours", value: "in 10 Stunden", locale: de, want: time.Date(2024, time.October, 17, 1, 30, 0, 0, defaultLocation)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRelativeWithLocale(tt.value, tt.locale, reference)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+
+	for _, value := range []string{"pasado", "ayeres", "hace horas", "hace 3 horas 11:53", "ayer 11:53 2024", "ayer hoy"} {
+		t.Run("Mismatch "+value, func(t *testing.T) {
+			_, err := ParseRelativeWithLocale(value, es, reference)
+			expected := newRelativeTimeMismatchError(value)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+
+	t.Run("UnsupportedRelativeTimes", func(t *testing.T) {
+		_, err := ParseRelativeWithLocale("ayer", &customLocale{es}, reference)
+		expected := newUnsupportedLayoutElemError("relative time", es)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("UnsupportedLocale", func(t *testing.T) {
+		_, err := ParseRelative("ayer", "ann", reference)
+		var e *ErrUnsupportedLocale
+		if !errors.As(err, &e) {
+			t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"ann"}, err)
+		}
+	})
+}
+
+func TestFormatRelative(t *testing.T) {
+	es := newTestLocale(LocaleEs, esRelativeTimesTestFields)
+	es.table[pluralRulesField] = []string{"one", "n = 1"}
+	es.table[narrowRelativeTimesField] = []string{"day:past:other", "-{0} d"}
+
+	pl := newTestLocale(LocalePl, map[int][]string{
+		longRelativeTimesField: {
+			"hour:future:one", "za {0} godzinę",
+			"hour:future:few", "za {0} godziny",
+			"hour:future:many", "za {0} godzin",
+			"hour:future:other", "za {0} godziny",
+		},
+	})
+	pl.table[pluralRulesField] = []string{
+		"one", "i = 1 and v = 0",
+		"few", "v = 0 and i % 10 = 2..4 and i % 100 != 12..14",
+		"many", "v = 0 and i != 1 and i % 10 = 0..1 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 12..14",
+	}
+
+	plNoRules := &genericLocale{lang: pl.lang, table: pl.table}
+	plNoRules.table[pluralRulesField] = nil
+
+	// Wednesday
+	reference := time.Date(2024, time.October, 16, 15, 30, 0, 0, defaultLocation)
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		locale Locale
+		style  RelativeTimeStyle
+		want   string
+	}{
+		{name: "Yesterday", t: time.Date(2024, time.October, 15, 9, 0, 0, 0, defaultLocation), locale: es, want: "ayer"},
+		{name: "PastDays", t: time.Date(2024, time.October, 13, 15, 30, 0, 0, defaultLocation), locale: es, want: "hace 3 días"},
+		{name: "PastHours", t: time.Date(2024, time.October, 16, 12, 0, 0, 0, defaultLocation), locale: es, want: "hace 3 horas"},
+		{name: "LastWeek", t: time.Date(2024, time.October, 8, 15, 30, 0, 0, defaultLocation), locale: es, want: "la semana pasada"},
+		{name: "FutureMonths", t: time.Date(2025, time.February, 20, 15, 30, 0, 0, defaultLocation), locale: es, want: "dentro de 4 meses"},
+		{name: "ShortPattern", t: time.Date(2024, time.October, 16, 13, 30, 0, 0, defaultLocation), locale: es, style: RelativeTimeShort, want: "hace 2 h"},
+		{name: "ShortFallback", t: time.Date(2024, time.October, 16, 14, 30, 0, 0, defaultLocation), locale: es, style: RelativeTimeShort, want: "hace 1 hora"},
+		{name: "NarrowPattern", t: time.Date(2024, time.October, 13, 15, 30, 0, 0, defaultLocation), locale: es, style: RelativeTimeNarrow, want: "-3 d"},
+		{name: "NarrowFallback", t: time.Date(2024, time.October, 16, 12, 30, 0, 0, defaultLocation), locale: es, style: RelativeTimeNarrow, want: "hace 3 h"},
+		{name: "PluralOne", t: time.Date(2024, time.October, 16, 16, 30, 0, 0, defaultLocation), locale: pl, want: "za 1 godzinę"},
+		{name: "PluralFew", t: time.Date(2024, time.October, 16, 18, 30, 0, 0, defaultLocation), locale: pl, want: "za 3 godziny"},
+		{name: "PluralMany", t: time.Date(2024, time.October, 16, 20, 30, 0, 0, defaultLocation), locale: pl, want: "za 5 godzin"},
+		{name: "PluralManyTeens", t: time.Date(2024, time.October, 17, 3, 30, 0, 0, defaultLocation), locale: pl, want: "za 12 godzin"},
+		{name: "PluralOther", t: time.Date(2024, time.October, 16, 18, 30, 0, 0, defaultLocation), locale: plNoRules, want: "za 3 godziny"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatRelativeWithLocale(tt.t, reference, tt.locale, tt.style)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("MissingPattern", func(t *testing.T) {
+		_, err := FormatRelativeWithLocale(reference.AddDate(-3, 0, 0), reference, es, RelativeTimeLong)
+		expected := newUnsupportedLayoutElemError("relative time", es)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	for _, style := range []RelativeTimeStyle{RelativeTimeLong - 1, RelativeTimeNarrow + 1} {
+		t.Run(fmt.Sprintf("UnknownStyle %d", style), func(t *testing.T) {
+			_, err := FormatRelativeWithLocale(reference, reference, es, style)
+			expected := newUnsupportedLayoutElemError("relative time style", es)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+
+	t.Run("UnsupportedLocale", func(t *testing.T) {
+		_, err := FormatRelative(reference, reference, "ann", RelativeTimeLong)
+		var e *ErrUnsupportedLocale
+		if !errors.As(err, &e) {
+			t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"ann"}, err)
+		}
+	})
+}
+
+func TestRelativeLocales(t *testing.T) {
+	skipUngeneratedFields(t, LocaleEs, longRelativeTimesField, shortRelativeTimesField, pluralRulesField)
+
+	// Wednesday
+	reference := time.Date(2024, time.October, 16, 15, 30, 0, 0, defaultLocation)
+
+	tests := []struct {
+		value string
+		t     time.Time
+		style RelativeTimeStyle
+	}{
+		{value: "ayer", t: time.Date(2024, time.October, 15, 15, 30, 0, 0, defaultLocation)},
+		{value: "anteayer", t: time.Date(2024, time.October, 14, 15, 30, 0, 0, defaultLocation)},
+		{value: "hace 3 días", t: time.Date(2024, time.October, 13, 15, 30, 0, 0, defaultLocation)},
+		{value: "hace 1 hora", t: time.Date(2024, time.October, 16, 14, 30, 0, 0, defaultLocation)},
+		{value: "hace 3 h", t: time.Date(2024, time.October, 16, 12, 30, 0, 0, defaultLocation), style: RelativeTimeShort},
+		{value: "la semana pasada", t: time.Date(2024, time.October, 9, 15, 30, 0, 0, defaultLocation)},
+		{value: "dentro de 4 meses", t: time.Date(2025, time.February, 16, 15, 30, 0, 0, defaultLocation)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRelative(tt.value, LocaleEs, reference)
+			if err != nil || !got.Equal(tt.t) {
+				t.Errorf("expected: %v, got: %v ('%v')", tt.t, got, err)
+			}
+
+			value, err := FormatRelative(tt.t, reference, LocaleEs, tt.style)
+			if err != nil || value != tt.value {
+				t.Errorf("expected: '%s', got: '%s' ('%v')", tt.value, value, err)
+			}
+		})
+	}
+}
+
+func TestRelativeAmount(t *testing.T) {
+	reference := time.Date(2024, time.October, 16, 15, 30, 0, 0, defaultLocation)
+
+	tests := []struct {
+		t      time.Time
+		field  string
+		amount int
+	}{
+		{t: reference, field: "second", amount: 0},
+		{t: reference.Add(-45 * time.Second), field: "second", amount: -45},
+		{t: reference.Add(59 * time.Minute), field: "minute", amount: 59},
+		{t: reference.Add(23 * time.Hour), field: "hour", amount: 23},
+		{t: reference.AddDate(0, 0, -6), field: "day", amount: -6},
+		{t: reference.AddDate(0, 0, 13), field: "week", amount: 1},
+		{t: reference.AddDate(0, 1, -1), field: "week", amount: 4},
+		{t: reference.AddDate(0, 1, 0), field: "month", amount: 1},
+		{t: reference.AddDate(0, -11, 0), field: "month", amount: -11},
+		{t: reference.AddDate(-2, -1, 0), field: "year", amount: -2},
+	}
+
+	for _, tt := range tests {
+		field, amount := relativeAmount(tt.t, reference)
+		if field != tt.field || amount != tt.amount {
+			t.Errorf("expected: %s %d for %v, got: %s %d", tt.field, tt.amount, tt.t, field, amount)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import "time"
+
+// ParseResult holds the time parsed by [ParseWithResult], and how the value names were
+// matched, so callers can flag the values parsed with low confidence.
+type ParseResult struct {
+	// Time is the parsed time.
+	Time time.Time
+	// Names holds the value names matched by the layout names elements, such as the days,
+	// months, day periods, and eras ones, in the value order.
+	Names []NameMatch
+}
+
+// Fuzzy reports whether any name was matched fuzzily, as the FuzzyNames option enables.
+func (r *ParseResult) Fuzzy() bool {
+	for _, name := range r.Names {
+		if name.Fuzzy {
+			return true
+		}
+	}
+	return false
+}
+
+// NameMatch describes the value name matched by a layout element.
+type NameMatch struct {
+	// LayoutElem is the layout element matching the name (e.g. "January").
+	LayoutElem string
+	// Value is the matched value name, and Offset its byte offset in the value.
+	Value  string
+	Offset int
+	// Language is the language of the locale whose names matched the value name, which is
+	// the layout locale one, unless a fallback locale matched it.
+	Language string
+	// Fuzzy indicates that the value name does not match any locale name exactly, and
+	// was matched to the closest one.
+	Fuzzy bool
+}
+
+// ParseWithResult is like ParseWithOptions, but it returns a ParseResult describing how the
+// value names were matched along with the parsed time.
+func ParseWithResult(layout string, value string, locale Locale, opts Options) (ParseResult, error) {
+	return parseResult(layout, nil, value, nil, locale, &opts)
+}
+
+// ParseInLocationWithResult is like ParseInLocationWithOptions, but it returns a ParseResult
+// describing how the value names were matched along with the parsed time.
+func ParseInLocationWithResult(layout string, value string, location *time.Location, locale Locale, opts Options) (ParseResult, error) {
+	return parseResult(layout, nil, value, location, locale, &opts)
+}
+
+// parseResult parses the value as parse does, recording the names read by parseNative. The
+// values it does not parse are read again once parsed, as the time package does, to find
+// their names.
+func parseResult(layout string, elems []layoutElem, value string, location *time.Location, locale Locale, opts *Options) (ParseResult, error) {
+	transliterated := transliterateDigits(value, locale)
+	p := newNativeParser()
+	p.recordNames = true
+	if p.read(layout, elems, transliterated, locale, opts) {
+		if t, ok := p.time(location); ok {
+			return ParseResult{Time: t, Names: p.nameMatches(value, locale)}, nil
+		}
+	}
+
+	t, err := parse(layout, elems, value, location, locale, opts)
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	p = newNativeParser()
+	p.diagnose, p.recordNames = true, true
+	p.read(layout, elems, transliterated, locale, opts)
+	return ParseResult{Time: t, Names: p.nameMatches(value, locale)}, nil
+}
+
+// nameMatches returns the recorded names, with their offsets and values located in the
+// value, whose digits might not be transliterated.
+func (p *nativeParser) nameMatches(value string, locale Locale) []NameMatch {
+	for i := range p.names {
+		name := &p.names[i]
+		start := untransliteratedOffset(value, name.Offset, locale)
+		end := untransliteratedOffset(value, name.Offset+len(name.Value), locale)
+		name.Value, name.Offset = value[start:end], start
+	}
+
+	return p.names
+}
@@ -0,0 +1,210 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strings"
+	"time"
+)
+
+// strftimeLayouts maps the strftime conversion specifications to the equivalent layout
+// elements. Specifications with no equivalent layout element are mapped to empty strings.
+var strftimeLayouts = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "3",
+	'M': "04",
+	'S': "05",
+	'y': "06",
+	'Y': "2006",
+	'm': "01",
+	'j': "002",
+	'p': "PM",
+	'P': "pm",
+	'Z': "MST",
+	'z': "-0700",
+	'D': "01/02/06",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'R': "15:04",
+	'r': "3:04:05 PM",
+	'n': "\n",
+	't': "\t",
+	'%': "%",
+}
+
+// strftimePaddedLayouts holds the zero padded layouts of the 12-hour clock specifications,
+// which are used to format them as strftime does, while their layouts parse the hours with
+// or without the padding, as strptime does.
+var strftimePaddedLayouts = map[byte]string{
+	'I': "03",
+	'r': "03:04:05 PM",
+}
+
+// strftimeStyles maps the locale dependent date and time representations to the date
+// and time styles of their locale layouts.
+var strftimeStyles = map[byte][2]FormatStyle{
+	'c': {Medium, Medium},
+	'x': {Short, NoStyle},
+	'X': {NoStyle, Medium},
+}
+
+// StrftimeToLayout converts the C strftime/strptime format (e.g. "%a %b %e %H:%M:%S %Y") to
+// the equivalent layout (e.g. "Mon Jan _2 15:04:05 2006"). The locale dependent date and
+// time representations (%c, %x, and %X) are converted to the locale layouts, built by
+// [LayoutForWithLocale] with the medium date and time, short date, and medium time styles.
+// If the locale has no date and time formats, they result in the LayoutForWithLocale error.
+// The 12-hour clock hours (%I) are converted to the non-padded layout element (3), so
+// the hours are parsed with or without the zero padding. The E and O modifiers (e.g. %Ex)
+// are ignored, and the Python microseconds (%f) are supported after a period or comma.
+//
+// Conversion specifications with no layout equivalent, such as week numbers (%U, %V, %W),
+// week days numbers (%u, %w), or space padded hours (%k, %l), and literals that would be
+// interpreted as layout elements result in an ErrUnsupportedPatternElem error.
+func StrftimeToLayout(format string, locale Locale) (string, error) {
+	return strftimeToLayout(format, locale, false)
+}
+
+// strftimeToLayout is like StrftimeToLayout, but if padded is set, the 12-hour clock
+// hours are converted to the zero padded layout element (03), as strftime formats them.
+func strftimeToLayout(format string, locale Locale, padded bool) (string, error) {
+	var sb strings.Builder
+	var literalOffset int
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		literal := format[literalOffset:i]
+		if containsLayoutElem(sb.String(), literal) {
+			return "", newUnsupportedPatternElemError(literal, format)
+		}
+		sb.WriteString(literal)
+
+		spec := i
+		i++
+		if i < len(format) && (format[i] == 'E' || format[i] == 'O') {
+			i++
+		}
+
+		if i >= len(format) {
+			return "", newUnsupportedPatternElemError(format[spec:], format)
+		}
+
+		elem, err := strftimeLayout(format[i], locale, sb.String(), padded)
+		if err != nil {
+			return "", err
+		}
+
+		if elem == "" {
+			return "", newUnsupportedPatternElemError(format[spec:i+1], format)
+		}
+
+		sb.WriteString(elem)
+		literalOffset = i + 1
+	}
+
+	literal := format[literalOffset:]
+	if containsLayoutElem(sb.String(), literal) {
+		return "", newUnsupportedPatternElemError(literal, format)
+	}
+	sb.WriteString(literal)
+
+	return sb.String(), nil
+}
+
+// strftimeLayout returns the layout equivalent to the conversion specification, or an
+// empty string if there is none.
+func strftimeLayout(spec byte, locale Locale, layout string, padded bool) (string, error) {
+	if styles, ok := strftimeStyles[spec]; ok {
+		return LayoutForWithLocale(locale, styles[0], styles[1])
+	}
+
+	if elem, ok := strftimePaddedLayouts[spec]; ok && padded {
+		return elem, nil
+	}
+
+	if spec == 'f' {
+		if strings.HasSuffix(layout, ".") || strings.HasSuffix(layout, ",") {
+			return "000000", nil
+		}
+		return "", nil
+	}
+
+	return strftimeLayouts[spec], nil
+}
+
+// ParseStrftime parses a formatted string in foreign language using the C strftime/strptime
+// format (e.g. "%A %d %B %Y"), which is converted to the equivalent layout by
+// [StrftimeToLayout], and returns the [time.Time] value it represents. See [Parse] for more
+// details.
+//
+// To execute several parses for the same locale, use [ParseStrftimeWithLocale] as it performs better.
+func ParseStrftime(format string, value string, lang string) (time.Time, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseStrftimeWithLocale(format, value, locale)
+}
+
+// ParseStrftimeWithLocale is like ParseStrftime, but instead of receiving a BCP 47 language
+// tag argument, it receives a built [lunes.Locale], avoiding looking up existing data in each
+// operation and allowing extensibility.
+func ParseStrftimeWithLocale(format string, value string, locale Locale) (time.Time, error) {
+	layout, err := StrftimeToLayout(format, locale)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseWithLocale(layout, value, locale)
+}
+
+// FormatStrftime returns a textual representation of the time value formatted according to
+// the C strftime format (e.g. "%A %d %B %Y"), using the names of the provided locale. The
+// 12-hour clock hours (%I) are zero padded, as strftime writes them. See [Format] for more
+// details.
+//
+// To execute several formats for the same locale, use [FormatStrftimeWithLocale] as it performs better.
+func FormatStrftime(t time.Time, format string, lang string) (string, error) {
+	locale, err := NewDefaultLocale(lang)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatStrftimeWithLocale(t, format, locale)
+}
+
+// FormatStrftimeWithLocale is like FormatStrftime, but instead of receiving a BCP 47 language
+// tag argument, it receives a built [lunes.Locale], avoiding looking up existing data in each
+// operation and allowing extensibility.
+func FormatStrftimeWithLocale(t time.Time, format string, locale Locale) (string, error) {
+	layout, err := strftimeToLayout(format, locale, true)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatWithLocale(t, layout, locale)
+}
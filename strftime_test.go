@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseStrftime(t *testing.T) {
+	pl := newTestLocale(LocalePl, plFormatsTestFields)
+
+	es, _ := NewDefaultLocale(LocaleEs)
+	de, _ := NewDefaultLocale(LocaleDe)
+	en, _ := NewDefaultLocale(LocaleEn)
+
+	tests := []struct {
+		name   string
+		format string
+		value  string
+		locale Locale
+		want   time.Time
+	}{
+		{name: "Syslog", format: "%a %b %e %H:%M:%S %Y", value: "mar oct  1 09:41:30 2024", locale: es, want: time.Date(2024, time.October, 1, 9, 41, 30, 0, time.UTC)},
+		{name: "LongNames", format: "%A, %d. %B %Y", value: "Dienstag, 15. Oktober 2024", locale: de, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "DayPeriod", format: "%D %I:%M %p", value: "10/15/24 09:41 PM", locale: en, want: time.Date(2024, time.October, 15, 21, 41, 0, 0, time.UTC)},
+		{name: "DayPeriodNotPadded", format: "%D %I:%M %p", value: "10/15/24 9:41 PM", locale: en, want: time.Date(2024, time.October, 15, 21, 41, 0, 0, time.UTC)},
+		{name: "Time12", format: "%r", value: "9:41:30 PM", locale: en, want: time.Date(0, time.January, 1, 21, 41, 30, 0, time.UTC)},
+		{name: "Composite", format: "%F %T %z", value: "2024-10-15 09:41:30 +0200", locale: en, want: time.Date(2024, time.October, 15, 7, 41, 30, 0, time.UTC)},
+		{name: "Microseconds", format: "%Y%m%d %H:%M:%S.%f", value: "20241015 09:41:30.123456", locale: en, want: time.Date(2024, time.October, 15, 9, 41, 30, 123456000, time.UTC)},
+		{name: "Percent", format: "%j%%%y", value: "289%24", locale: en, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "Modifiers", format: "%Ey-%Om-%Od", value: "24-10-15", locale: en, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "LocaleDate", format: "%x", value: "15.10.2024", locale: pl, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "LocaleDateTime", format: "%c", value: "15 paź 2024, 09:41:30", locale: pl, want: time.Date(2024, time.October, 15, 9, 41, 30, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStrftimeWithLocale(tt.format, tt.value, tt.locale)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+
+	unsupported := []struct {
+		format string
+		elem   string
+	}{
+		{format: "%Y-W%V", elem: "%V"},
+		{format: "%k:%M", elem: "%k"},
+		{format: "%S%f", elem: "%f"},
+		{format: "%Y %", elem: "%"},
+		{format: "Mon %d", elem: "Mon "},
+		{format: "%d 1", elem: " 1"},
+	}
+
+	for _, tt := range unsupported {
+		t.Run("Unsupported "+tt.format, func(t *testing.T) {
+			_, err := ParseStrftime(tt.format, "", LocaleEn)
+			expected := newUnsupportedPatternElemError(tt.elem, tt.format)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+
+	for _, format := range []string{"%x", "%X", "%c"} {
+		t.Run("UnsupportedFormats "+format, func(t *testing.T) {
+			locale := &customLocale{pl}
+			_, err := ParseStrftimeWithLocale(format, "", locale)
+			expected := newUnsupportedLayoutElemError("date time format", locale)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error: '%v', got: '%v'", expected, err)
+			}
+		})
+	}
+
+	t.Run("UnsupportedLocale", func(t *testing.T) {
+		_, err := ParseStrftime("%Y", "2024", "ann")
+		var e *ErrUnsupportedLocale
+		if !errors.As(err, &e) {
+			t.Errorf("expected error: '%v', got: '%v'", &ErrUnsupportedLocale{"ann"}, err)
+		}
+	})
+}
+
+func TestParseStrftimeLocales(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+		lang   string
+		want   time.Time
+	}{
+		{format: "%x", value: "15.10.24", lang: LocaleDe, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{format: "%X", value: "09:41:30", lang: LocaleDe, want: time.Date(0, time.January, 1, 9, 41, 30, 0, time.UTC)},
+		{format: "%c", value: "15.10.2024, 09:41:30", lang: LocaleDe, want: time.Date(2024, time.October, 15, 9, 41, 30, 0, time.UTC)},
+		{format: "%x", value: "10/15/24", lang: LocaleEnUS, want: time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.format, func(t *testing.T) {
+			skipUngeneratedFields(t, tt.lang, dateFormatsField, timeFormatsField, dateTimeFormatsField, hourCycleField)
+
+			got, err := ParseStrftime(tt.format, tt.value, tt.lang)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("expected: %v, got: %v ('%v')", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestFormatStrftime(t *testing.T) {
+	value := time.Date(2024, time.October, 5, 21, 41, 30, 123456000, time.UTC)
+
+	tests := []struct {
+		format string
+		lang   string
+		want   string
+	}{
+		{format: "%A %d %B %Y", lang: LocaleEs, want: "sábado 05 octubre 2024"},
+		{format: "%a %b %e %H:%M:%S %Y", lang: LocaleDe, want: "Sa. Okt.  5 21:41:30 2024"},
+		{format: "%I:%M %p", lang: LocaleEn, want: "09:41 PM"},
+		{format: "%r", lang: LocaleEn, want: "09:41:30 PM"},
+		{format: "%T.%f%n%%", lang: LocaleEn, want: "21:41:30.123456\n%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := FormatStrftime(value, tt.format, tt.lang)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected: '%s', got: '%s'", tt.want, got)
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ import (
 )
 
 func TestLocaleTableEn(t *testing.T) {
-	localeStdTable := [5][]string{
+	localeStdTable := [localeTableSize][]string{
 		shortDayNamesStd,
 		longDayNamesStd,
 		shortMonthNamesStd,
@@ -108,7 +108,7 @@ func TestLocaleTableEnAuOverrides(t *testing.T) {
 	}
 }
 
-func testTableEquality(t *testing.T, lang string, value, expected [5][]string, field, from, to int) {
+func testTableEquality(t *testing.T, lang string, value, expected [localeTableSize][]string, field, from, to int) {
 	var fieldName string
 	switch field {
 	case shortDayNamesField:
@@ -171,4 +171,73 @@ func TestConstFieldsOrder(t *testing.T) {
 			t.Errorf("dayPeriodsField value must be 4")
 		}
 	})
+
+	t.Run("standAloneShortDayNamesField", func(t *testing.T) {
+		if standAloneShortDayNamesField != 5 {
+			t.Errorf("standAloneShortDayNamesField value must be 5")
+		}
+	})
+
+	t.Run("standAloneLongDayNamesField", func(t *testing.T) {
+		if standAloneLongDayNamesField != 6 {
+			t.Errorf("standAloneLongDayNamesField value must be 6")
+		}
+	})
+
+	t.Run("standAloneShortMonthNamesField", func(t *testing.T) {
+		if standAloneShortMonthNamesField != 7 {
+			t.Errorf("standAloneShortMonthNamesField value must be 7")
+		}
+	})
+
+	t.Run("standAloneLongMonthNamesField", func(t *testing.T) {
+		if standAloneLongMonthNamesField != 8 {
+			t.Errorf("standAloneLongMonthNamesField value must be 8")
+		}
+	})
+
+	t.Run("minDayNamesField", func(t *testing.T) {
+		if minDayNamesField != 9 {
+			t.Errorf("minDayNamesField value must be 9")
+		}
+	})
+
+	t.Run("narrowDayNamesField", func(t *testing.T) {
+		if narrowDayNamesField != 10 {
+			t.Errorf("narrowDayNamesField value must be 10")
+		}
+	})
+
+	t.Run("narrowMonthNamesField", func(t *testing.T) {
+		if narrowMonthNamesField != 11 {
+			t.Errorf("narrowMonthNamesField value must be 11")
+		}
+	})
+}
+
+// newTestLocale returns the lang default locale with the given table fields replaced, to test
+// the data the generated tables do not have, or locales the CLDR does not define.
+func newTestLocale(lang string, fields map[int][]string) *genericLocale {
+	table, _ := getTable(lang)
+	for field, values := range fields {
+		table[field] = values
+	}
+	return &genericLocale{lang: lang, table: table}
+}
+
+// skipUngeneratedFields skips the test if the lang table has no values for any of the fields,
+// as the tables.go file must be generated from a CLDR release including them.
+func skipUngeneratedFields(t *testing.T, lang string, fields ...int) {
+	t.Helper()
+
+	table, ok := getTable(lang)
+	if !ok {
+		t.Fatalf("unknown locale %q", lang)
+	}
+
+	for _, field := range fields {
+		if len(table[field]) == 0 {
+			t.Skipf("the %q table field %d is not generated, run go generate to populate it", lang, field)
+		}
+	}
 }
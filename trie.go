@@ -0,0 +1,290 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	triesMu    sync.RWMutex
+	triesCache = make(map[string]*localeTries)
+)
+
+// localeTries holds the names tries of a locale table, built lazily for each ordered
+// combination of names fields looked up by the layout elements.
+type localeTries struct {
+	mu    sync.Mutex
+	tries atomic.Pointer[[]fieldsTrie]
+}
+
+type fieldsTrie struct {
+	fields uint32
+	trie   *nameTrie
+}
+
+// getLocaleTries returns the names tries of the language, sharing them between all
+// the default locales of the same language.
+func getLocaleTries(lang string) *localeTries {
+	triesMu.RLock()
+	tries, ok := triesCache[lang]
+	triesMu.RUnlock()
+	if ok {
+		return tries
+	}
+
+	triesMu.Lock()
+	defer triesMu.Unlock()
+
+	if tries, ok := triesCache[lang]; ok {
+		return tries
+	}
+
+	tries = &localeTries{}
+	triesCache[lang] = tries
+	return tries
+}
+
+// get returns the trie of the table fields, building it on first use. The fields are
+// packed in order, using 5 bits for each field index plus one.
+func (l *localeTries) get(table *[localeTableSize][]string, fields uint32) *nameTrie {
+	if trie := l.find(fields); trie != nil {
+		return trie
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if trie := l.find(fields); trie != nil {
+		return trie
+	}
+
+	var tabs [][]string
+	for f := fields; f != 0; f >>= 5 {
+		tabs = append(tabs, table[f&0x1f-1])
+	}
+
+	var tries []fieldsTrie
+	if p := l.tries.Load(); p != nil {
+		tries = append(tries, *p...)
+	}
+
+	trie := newNameTrie(tabs...)
+	tries = append(tries, fieldsTrie{fields: fields, trie: trie})
+	l.tries.Store(&tries)
+	return trie
+}
+
+func (l *localeTries) find(fields uint32) *nameTrie {
+	if p := l.tries.Load(); p != nil {
+		for _, t := range *p {
+			if t.fields == fields {
+				return t.trie
+			}
+		}
+	}
+	return nil
+}
+
+// nameTrie matches the longest name of a set of lookup tables in a single pass over the
+// value. The names are case-folded when the trie is built, so it matches the same values
+// as strings.EqualFold does.
+type nameTrie struct {
+	nodes []trieNode
+	edges []trieEdge
+}
+
+type trieNode struct {
+	// edges holds the node edges range, sorted by rune.
+	edgesStart, edgesEnd int32
+	// index holds the index of the name ending at the node, or -1.
+	index int32
+	// ambiguous is set when more than one name index ends at the node.
+	ambiguous bool
+}
+
+type trieEdge struct {
+	r    rune
+	node int32
+}
+
+// newNameTrie builds the trie of the lookup tables names, which must be sorted in the same
+// order. Names sharing the same folded value are resolved to the first index, as lookup does.
+func newNameTrie(lookupTabs ...[]string) *nameTrie {
+	type buildNode struct {
+		children  map[rune]int
+		index     int
+		ambiguous bool
+	}
+
+	nodes := []buildNode{{index: -1}}
+	for _, names := range lookupTabs {
+		for i, name := range names {
+			n := 0
+			for _, r := range name {
+				r = foldRune(r)
+				next, ok := nodes[n].children[r]
+				if !ok {
+					if nodes[n].children == nil {
+						nodes[n].children = make(map[rune]int)
+					}
+					next = len(nodes)
+					nodes[n].children[r] = next
+					nodes = append(nodes, buildNode{index: -1})
+				}
+				n = next
+			}
+
+			if nodes[n].index < 0 {
+				nodes[n].index = i
+			} else if nodes[n].index != i {
+				nodes[n].ambiguous = true
+			}
+		}
+	}
+
+	t := &nameTrie{nodes: make([]trieNode, len(nodes))}
+	for i, node := range nodes {
+		start := len(t.edges)
+		for r, next := range node.children {
+			t.edges = append(t.edges, trieEdge{r: r, node: int32(next)})
+		}
+		edges := t.edges[start:]
+		sort.Slice(edges, func(a, b int) bool { return edges[a].r < edges[b].r })
+
+		t.nodes[i] = trieNode{
+			edgesStart: int32(start),
+			edgesEnd:   int32(len(t.edges)),
+			index:      int32(node.index),
+			ambiguous:  node.ambiguous,
+		}
+	}
+
+	return t
+}
+
+// match returns the index and the byte length of the longest name matching the value
+// starting at the offset position, or -1 if none matches. If the longest match is shared
+// by more than one index, the first one is returned, and ambiguous is set to true.
+func (t *nameTrie) match(value string, offset int) (index int, length int, ambiguous bool) {
+	nodes, edges := t.nodes, t.edges
+	node := &nodes[0]
+	// empty names match without consuming the value, and are never ambiguous
+	index = int(node.index)
+
+	for i := offset; i < len(value); {
+		r, size := rune(value[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRuneInString(value[i:])
+		}
+		r = foldRune(r)
+
+		// most nodes have a few edges, so they are scanned linearly
+		lo, hi := node.edgesStart, node.edgesEnd
+		for hi-lo > 8 {
+			mid := int32(uint32(lo+hi) >> 1)
+			if edges[mid].r < r {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		next := int32(-1)
+		for ; lo < node.edgesEnd && edges[lo].r <= r; lo++ {
+			if edges[lo].r == r {
+				next = edges[lo].node
+				break
+			}
+		}
+
+		if next < 0 {
+			break
+		}
+
+		i += size
+		node = &nodes[next]
+		if node.index >= 0 {
+			index, length, ambiguous = int(node.index), i-offset, node.ambiguous
+		}
+	}
+
+	return index, length, ambiguous
+}
+
+// foldRune returns the smallest rune of the r case folding orbit, so all the runes
+// considered equal by strings.EqualFold fold to the same value.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return r
+	}
+
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < folded {
+			folded = f
+		}
+	}
+	return folded
+}
+
+// namesTrie returns the trie of the lookup tables, or nil if the locale has no tries, or
+// if any of the tables is not one of its names fields.
+func (g *genericLocale) namesTrie(lookupTabs ...[]string) *nameTrie {
+	if g.tries == nil || len(lookupTabs) > 6 {
+		return nil
+	}
+
+	var fields uint32
+	for i := len(lookupTabs) - 1; i >= 0; i-- {
+		// empty tables match nothing
+		if len(lookupTabs[i]) == 0 {
+			continue
+		}
+
+		field := g.namesField(lookupTabs[i])
+		if field < 0 {
+			return nil
+		}
+		fields = fields<<5 | uint32(field+1)
+	}
+
+	if fields == 0 {
+		return nil
+	}
+	return g.tries.get(&g.table, fields)
+}
+
+// namesField returns the table field holding the names, or -1 if none does.
+func (g *genericLocale) namesField(names []string) int {
+	// only the names fields are matched by lookup
+	for field := shortDayNamesField; field <= narrowFlexibleDayPeriodsField; field++ {
+		tab := g.table[field]
+		if len(tab) == len(names) && &tab[0] == &names[0] {
+			return field
+		}
+	}
+	return -1
+}
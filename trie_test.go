@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNameTrieMatch(t *testing.T) {
+	trie := newNameTrie([]string{"Jan", "Janv.", "juin", "JUIN", "", "Ωμέγα"}, []string{"janv", "", "", "juin."})
+
+	tests := []struct {
+		value     string
+		offset    int
+		index     int
+		length    int
+		ambiguous bool
+	}{
+		{value: "janv. 2024", index: 1, length: 5},
+		{value: "JAN 2024", index: 0, length: 3},
+		{value: "Juin", index: 2, length: 4, ambiguous: true},
+		{value: "Janv 2024", index: 0, length: 4},
+		{value: "juin. 2024", index: 3, length: 5},
+		{value: "2 ωμέγα", offset: 2, index: 5, length: len("ωμέγα")},
+		{value: "Ju", index: 4},
+		{value: "", index: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			index, length, ambiguous := trie.match(tt.value, tt.offset)
+			if index != tt.index || length != tt.length || ambiguous != tt.ambiguous {
+				t.Errorf("expected: (%d, %d, %t), got: (%d, %d, %t)", tt.index, tt.length, tt.ambiguous, index, length, ambiguous)
+			}
+		})
+	}
+
+	index, _, _ := newNameTrie([]string{"Jan"}).match("Ju", 0)
+	if index != -1 {
+		t.Errorf("expected no match, got index: %d", index)
+	}
+}
+
+func TestLookupNamesTries(t *testing.T) {
+	for lang, loader := range tableLoaders {
+		table := loader()
+		for _, fields := range [][]int{
+			{longMonthNamesField, shortMonthNamesField, standAloneLongMonthNamesField, narrowMonthNamesField},
+			{longDayNamesField, shortDayNamesField, minDayNamesField, narrowDayNamesField},
+			{dayPeriodsField},
+			{longFlexibleDayPeriodsField, shortFlexibleDayPeriodsField, narrowFlexibleDayPeriodsField},
+			{longEraNamesField, shortEraNamesField, narrowEraNamesField},
+		} {
+			var tabs [][]string
+			for _, field := range fields {
+				tabs = append(tabs, table[field])
+			}
+
+			trie := newNameTrie(tabs...)
+			for _, tab := range tabs {
+				for _, name := range tab {
+					for _, value := range []string{name, strings.ToUpper(name), strings.ToLower(name) + " 2024", " " + name[:len(name)/2]} {
+						offset, spaces, index, matched, ambiguous := lookup(0, value, tabs...)
+						trieOffset, trieSpaces, trieIndex, trieMatched, trieAmbiguous := lookupNames(0, value, trie, nil, tabs...)
+						if offset != trieOffset || spaces != trieSpaces || index != trieIndex || matched != trieMatched || ambiguous != trieAmbiguous {
+							t.Errorf("%s fields %v value '%s': expected: (%d, %d, %d, '%s', %t), got: (%d, %d, %d, '%s', %t)",
+								lang, fields, value, offset, spaces, index, matched, ambiguous, trieOffset, trieSpaces, trieIndex, trieMatched, trieAmbiguous)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDefaultLocaleTries(t *testing.T) {
+	locale, err := NewDefaultLocale(LocaleFr)
+	if err != nil {
+		t.Fatalf("expected no error, got: '%v'", err)
+	}
+
+	g := locale.(*genericLocale)
+	trie := g.namesTrie(locale.LongMonthNames(), locale.ShortMonthNames())
+	if trie == nil {
+		t.Fatal("expected the months names trie")
+	}
+
+	other, _ := NewDefaultLocale(LocaleFr)
+	if got := other.(*genericLocale).namesTrie(other.LongMonthNames(), other.ShortMonthNames()); got != trie {
+		t.Error("expected the tries to be shared by the default locales of the same language")
+	}
+
+	if got := g.namesTrie(locale.ShortMonthNames(), locale.LongMonthNames()); got == nil || got == trie {
+		t.Error("expected a different trie for the same tables in a different order")
+	}
+
+	if got := g.namesTrie([]string{"janvier"}); got != nil {
+		t.Error("expected no trie for names outside of the locale table")
+	}
+
+	if got := (&genericLocale{lang: LocaleFr, table: g.table}).namesTrie(locale.LongMonthNames()); got != nil {
+		t.Error("expected no trie for locales built without tries")
+	}
+}
@@ -0,0 +1,237 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	gmtFormatIndex = iota
+	hourFormatIndex
+	gmtZeroFormatIndex
+	regionFormatIndex
+	regionFormatDaylightIndex
+	regionFormatStandardIndex
+)
+
+// rootTimeZoneFormats holds the CLDR root locale time zones formats, used by locales that
+// do not provide them.
+var rootTimeZoneFormats = []string{"GMT{0}", "+HH:mm;-HH:mm", "GMT", "{0}", "{0} (+1)", "{0} (+0)"}
+
+// zoneMatch holds the longest time zone value matched so far.
+type zoneMatch struct {
+	length int
+	// zone is a time zone identifier (e.g. "Europe/Paris") or a CLDR metazone
+	// (e.g. "Europe_Central"), unless location is set.
+	zone     string
+	location *time.Location
+}
+
+func (m *zoneMatch) update(length int, zone string, location *time.Location) {
+	if length > m.length {
+		m.length, m.zone, m.location = length, zone, location
+	}
+}
+
+// timeZoneData holds the CLDR supplemental data the time zones names are resolved with.
+type timeZoneData struct {
+	// metaZoneLocations maps the metazones to their golden zones.
+	metaZoneLocations map[string]string
+	// timeZoneIDs holds the time zones identifiers used by the metazones.
+	timeZoneIDs []string
+}
+
+// cldrTimeZoneData is the generated CLDR supplemental time zones data.
+var cldrTimeZoneData = timeZoneData{metaZoneLocations: metaZoneLocations, timeZoneIDs: timeZoneIDs}
+
+// matchTimeZone matches the localized time zone value starting at the offset position,
+// which might be a zone or metazone name (e.g. "hora de verano de Europa central"), an
+// exemplar city using the locale region format (e.g. "hora de Madrid"), or a localized
+// GMT format (e.g. "GMT+02:00"). It returns the value length and its location, or a
+// nil location if the value does not match any time zone.
+func matchTimeZone(value string, offset int, locale Locale) (int, *time.Location, error) {
+	return cldrTimeZoneData.matchTimeZone(value, offset, locale)
+}
+
+func (d *timeZoneData) matchTimeZone(value string, offset int, locale Locale) (int, *time.Location, error) {
+	formats := rootTimeZoneFormats
+	var names [2][]string
+	var cities []string
+	if zones, ok := locale.(TimeZoneLocale); ok {
+		if f := zones.TimeZoneFormats(); len(f) == len(rootTimeZoneFormats) {
+			formats = f
+		}
+		names = [2][]string{zones.LongTimeZoneNames(), zones.ShortTimeZoneNames()}
+		cities = zones.ExemplarCities()
+	}
+
+	val := value[offset:]
+	var match zoneMatch
+
+	for _, tab := range names {
+		for i := 0; i+1 < len(tab); i += 2 {
+			if hasPrefixFold(val, tab[i+1]) && d.metaZoneExists(tab[i]) {
+				match.update(len(tab[i+1]), tab[i], nil)
+			}
+		}
+	}
+
+	for _, format := range formats[regionFormatIndex:] {
+		prefix, suffix, ok := strings.Cut(format, "{0}")
+		if !ok || !hasPrefixFold(val, prefix) {
+			continue
+		}
+
+		rest := val[len(prefix):]
+		matchCity := func(zone, city string) {
+			if city != "" && hasPrefixFold(rest, city) && hasPrefixFold(rest[len(city):], suffix) {
+				match.update(len(prefix)+len(city)+len(suffix), zone, nil)
+			}
+		}
+
+		for i := 0; i+1 < len(cities); i += 2 {
+			matchCity(cities[i], cities[i+1])
+		}
+
+		for _, zone := range d.timeZoneIDs {
+			matchCity(zone, exemplarCity(zone))
+		}
+	}
+
+	gmtFormats := []string{formats[gmtFormatIndex], "GMT{0}", "UTC{0}"}
+	for _, format := range gmtFormats {
+		prefix, suffix, ok := strings.Cut(format, "{0}")
+		if !ok || !hasPrefixFold(val, prefix) {
+			continue
+		}
+
+		n, seconds, ok := parseZoneOffset(val[len(prefix):], formats[hourFormatIndex])
+		if !ok || !hasPrefixFold(val[len(prefix)+n:], suffix) {
+			continue
+		}
+
+		location := time.UTC
+		if seconds != 0 {
+			location = time.FixedZone("", seconds)
+		}
+		match.update(len(prefix)+n+len(suffix), "", location)
+	}
+
+	for _, zero := range []string{formats[gmtZeroFormatIndex], "GMT", "UTC"} {
+		if zero != "" && hasPrefixFold(val, zero) {
+			match.update(len(zero), "", time.UTC)
+		}
+	}
+
+	if match.length == 0 || match.location != nil {
+		return match.length, match.location, nil
+	}
+
+	zone := match.zone
+	if location, ok := d.metaZoneLocations[zone]; ok {
+		zone = location
+	}
+
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return match.length, location, nil
+}
+
+// metaZoneExists reports whether the zone is a time zone identifier, or a metazone with
+// a known golden zone.
+func (d *timeZoneData) metaZoneExists(zone string) bool {
+	if strings.Contains(zone, "/") {
+		return true
+	}
+
+	_, ok := d.metaZoneLocations[zone]
+	return ok
+}
+
+// exemplarCity returns the CLDR root exemplar city of the time zone, which is the last
+// identifier segment, using spaces instead of underscores (e.g. "Buenos Aires").
+func exemplarCity(zone string) string {
+	return strings.ReplaceAll(zone[strings.LastIndexByte(zone, '/')+1:], "_", " ")
+}
+
+// parseZoneOffset parses the signed hours and optional minutes of a GMT format value (e.g.
+// "+3", "-03:30"), using the CLDR hour format separator, or a colon. It returns the value
+// length and the offset in seconds.
+func parseZoneOffset(value string, hourFormat string) (n int, seconds int, ok bool) {
+	sign, size := utf8.DecodeRuneInString(value)
+	switch sign {
+	case '+':
+		sign = 1
+	case '-', '−':
+		sign = -1
+	default:
+		return 0, 0, false
+	}
+
+	n = size
+	hours, digits := parseZoneDigits(value[n:], 2)
+	if digits == 0 || hours > 23 {
+		return 0, 0, false
+	}
+	n += digits
+
+	for _, separator := range []string{hourFormatSeparator(hourFormat), ":"} {
+		if !strings.HasPrefix(value[n:], separator) {
+			continue
+		}
+
+		minutes, digits := parseZoneDigits(value[n+len(separator):], 2)
+		if digits == 2 && minutes < 60 {
+			n += len(separator) + digits
+			return n, int(sign) * (hours*3600 + minutes*60), true
+		}
+	}
+
+	return n, int(sign) * hours * 3600, true
+}
+
+// parseZoneDigits parses up to max ASCII digits, returning their value and count.
+func parseZoneDigits(value string, max int) (v int, digits int) {
+	for digits < max && digits < len(value) && value[digits] >= '0' && value[digits] <= '9' {
+		v = v*10 + int(value[digits]-'0')
+		digits++
+	}
+	return v, digits
+}
+
+// hourFormatSeparator returns the separator between the hours and minutes of the
+// positive CLDR hour format (e.g. ":" for "+HH:mm;-HH:mm").
+func hourFormatSeparator(hourFormat string) string {
+	positive, _, _ := strings.Cut(hourFormat, ";")
+	hours := strings.LastIndexByte(positive, 'H')
+	minutes := strings.IndexByte(positive, 'm')
+	if hours < 0 || minutes <= hours {
+		return ""
+	}
+	return positive[hours+1 : minutes]
+}
+
+func hasPrefixFold(value, prefix string) bool {
+	return len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix)
+}
@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lunes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testTimeZoneData is the supplemental data resolving the newTimeZoneTestLocale metazones,
+// which are not resolved by the parsing functions unless the tables are generated.
+var testTimeZoneData = timeZoneData{
+	metaZoneLocations: map[string]string{"Europe_Central": "Europe/Paris"},
+	timeZoneIDs:       []string{"Europe/Madrid"},
+}
+
+var timeZoneTestFields = map[int][]string{
+	timeZoneFormatsField: {"GMT{0}", "+HH:mm;-HH:mm", "GMT", "hora de {0}", "horario de verano de {0}", "horario estándar de {0}"},
+	longZoneNamesField: {
+		"Europe/Dublin", "hora estándar de Irlanda",
+		"Europe_Central", "hora de Europa central",
+		"Europe_Central", "hora estándar de Europa central",
+		"Europe_Central", "hora de verano de Europa central",
+	},
+	shortZoneNamesField: {"Europe_Central", "CET"},
+	exemplarCitiesField: {"Europe/London", "Londres"},
+}
+
+func TestParseTimeZoneNames(t *testing.T) {
+	locale := newTestLocale(LocaleEs, timeZoneTestFields)
+	opts := Options{TimeZoneNames: true}
+
+	tests := []struct {
+		name   string
+		layout string
+		value  string
+		zone   string
+		offset int
+		want   time.Time
+	}{
+		{
+			name:   "TimeZoneName",
+			layout: "15:04 MST 02/01/2006",
+			value:  "11:53 hora estándar de Irlanda 27/12/1988",
+			zone:   "Europe/Dublin",
+			want:   time.Date(1988, time.December, 27, 11, 53, 0, 0, time.UTC),
+		},
+		{
+			name:   "ExemplarCity",
+			layout: "15:04 MST 02/01/2006",
+			value:  "11:53 horario de verano de Londres 27/07/1988",
+			zone:   "Europe/London",
+			want:   time.Date(1988, time.July, 27, 11, 53, 0, 0, time.UTC),
+		},
+		{
+			name:   "GMTFormat",
+			layout: "15:04 MST 02/01/2006",
+			value:  "11:53 GMT+02:00 27/10/1988",
+			offset: 2 * 3600,
+			want:   time.Date(1988, time.October, 27, 11, 53, 0, 0, time.UTC),
+		},
+		{
+			name:   "UTCFormatHours",
+			layout: "15:04 MST",
+			value:  "11:53 UTC−3",
+			offset: -3 * 3600,
+			want:   time.Date(0, time.January, 1, 11, 53, 0, 0, time.UTC),
+		},
+		{
+			name:   "GMTZeroFormat",
+			layout: "15:04 MST",
+			value:  "11:53 GMT",
+			zone:   "UTC",
+			want:   time.Date(0, time.January, 1, 11, 53, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInLocationWithOptions(tt.layout, tt.value, defaultLocation, locale, opts)
+			if err != nil {
+				t.Fatalf("expected no error, got: '%v'", err)
+			}
+
+			location := time.FixedZone("", tt.offset)
+			if tt.zone != "" {
+				location, err = time.LoadLocation(tt.zone)
+				if err != nil {
+					t.Fatalf("expected no error, got: '%v'", err)
+				}
+			}
+
+			want := time.Date(tt.want.Year(), tt.want.Month(), tt.want.Day(), tt.want.Hour(), tt.want.Minute(), tt.want.Second(), 0, location)
+			if !got.Equal(want) {
+				t.Errorf("expected: %v, got: %v", want, got)
+			}
+
+			if tt.zone != "" && got.Location().String() != tt.zone {
+				t.Errorf("expected location %q, got: %q", tt.zone, got.Location())
+			}
+		})
+	}
+
+	t.Run("UnknownTimeZone", func(t *testing.T) {
+		value := "11:53 hora de Narnia"
+		_, err := ParseWithOptions("15:04 MST", value, locale, opts)
+		expected := newLayoutMismatchError("MST", value)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected error: '%v', got: '%v'", expected, err)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := ParseWithOptions("15:04 MST", "11:53 hora estándar de Irlanda", locale, Options{})
+		if err == nil {
+			t.Error("expected error, got: nil")
+		}
+	})
+
+	t.Run("TranslateIgnoresOption", func(t *testing.T) {
+		got, err := TranslateWithOptions("15:04 MST", "11:53 GMT+02:00", locale, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		if got != "11:53 GMT+02:00" {
+			t.Errorf("expected value \"11:53 GMT+02:00\", got: %q", got)
+		}
+	})
+
+	t.Run("NonTimeZoneLocale", func(t *testing.T) {
+		got, err := ParseWithOptions("15:04 MST", "11:53 GMT-05:30", &customLocale{locale}, opts)
+		if err != nil {
+			t.Fatalf("expected no error, got: '%v'", err)
+		}
+
+		if _, offset := got.Zone(); offset != -(5*3600 + 30*60) {
+			t.Errorf("expected offset %d, got: %d", -(5*3600 + 30*60), offset)
+		}
+	})
+}
+
+func TestMatchMetaZones(t *testing.T) {
+	locale := newTestLocale(LocaleEs, timeZoneTestFields)
+
+	tests := []struct {
+		value  string
+		length int
+		zone   string
+	}{
+		{value: "hora de verano de Europa central 1988", length: len("hora de verano de Europa central"), zone: "Europe/Paris"},
+		{value: "CET 1988", length: len("CET"), zone: "Europe/Paris"},
+		{value: "hora de Madrid 1988", length: len("hora de Madrid"), zone: "Europe/Madrid"},
+		{value: "hora estándar de Irlanda", length: len("hora estándar de Irlanda"), zone: "Europe/Dublin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			n, location, err := testTimeZoneData.matchTimeZone(tt.value, 0, locale)
+			if err != nil || n != tt.length || location == nil || location.String() != tt.zone {
+				t.Errorf("expected: %d %q, got: %d %v ('%v')", tt.length, tt.zone, n, location, err)
+			}
+		})
+	}
+
+	// the metazones with no golden zone are not matched
+	var data timeZoneData
+	if n, location, err := data.matchTimeZone("hora de Europa central", 0, locale); n != 0 || location != nil || err != nil {
+		t.Errorf("expected no match, got: %d %v ('%v')", n, location, err)
+	}
+}
+
+func TestParseTimeZoneNamesLocales(t *testing.T) {
+	skipUngeneratedFields(t, LocaleEs, longZoneNamesField)
+	if len(timeZoneIDs) == 0 {
+		t.Skip("the metazones are not generated, run go generate to populate them")
+	}
+
+	es, _ := NewDefaultLocale(LocaleEs)
+	opts := Options{TimeZoneNames: true}
+
+	tests := []struct {
+		layout string
+		value  string
+		zone   string
+	}{
+		{layout: "02 de January de 2006, 15:04:05 MST", value: "27 de octubre de 1988, 11:53:29 hora de verano de Europa central", zone: "Europe/Paris"},
+		{layout: "15:04 MST 02/01/2006", value: "11:53 hora de Madrid 27/07/1988", zone: "Europe/Madrid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseWithOptions(tt.layout, tt.value, es, opts)
+			if err != nil || got.Location().String() != tt.zone {
+				t.Errorf("expected location %q, got: %v ('%v')", tt.zone, got, err)
+			}
+		})
+	}
+}
+
+func TestParseZoneOffset(t *testing.T) {
+	tests := []struct {
+		value      string
+		hourFormat string
+		n          int
+		seconds    int
+		ok         bool
+	}{
+		{value: "+3", hourFormat: "+HH:mm;-HH:mm", n: 2, seconds: 3 * 3600, ok: true},
+		{value: "+03:30", hourFormat: "+HH:mm;-HH:mm", n: 6, seconds: 3*3600 + 30*60, ok: true},
+		{value: "-03.30", hourFormat: "+HH.mm;-HH.mm", n: 6, seconds: -(3*3600 + 30*60), ok: true},
+		{value: "−0330", hourFormat: "+HHmm;−HHmm", n: 7, seconds: -(3*3600 + 30*60), ok: true},
+		{value: "+03:3", hourFormat: "+HH:mm;-HH:mm", n: 3, seconds: 3 * 3600, ok: true},
+		{value: "+24", hourFormat: "+HH:mm;-HH:mm"},
+		{value: "3", hourFormat: "+HH:mm;-HH:mm"},
+		{value: "+", hourFormat: "+HH:mm;-HH:mm"},
+	}
+
+	for _, tt := range tests {
+		n, seconds, ok := parseZoneOffset(tt.value, tt.hourFormat)
+		if n != tt.n || seconds != tt.seconds || ok != tt.ok {
+			t.Errorf("%q: expected (%d, %d, %v), got: (%d, %d, %v)", tt.value, tt.n, tt.seconds, tt.ok, n, seconds, ok)
+		}
+	}
+}